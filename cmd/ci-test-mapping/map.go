@@ -20,6 +20,7 @@ import (
 	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
 	"github.com/openshift-eng/ci-test-mapping/pkg/bigquery"
 	"github.com/openshift-eng/ci-test-mapping/pkg/components"
+	componentconfig "github.com/openshift-eng/ci-test-mapping/pkg/config"
 	"github.com/openshift-eng/ci-test-mapping/pkg/jira"
 	"github.com/openshift-eng/ci-test-mapping/pkg/obsoletetests"
 	"github.com/openshift-eng/ci-test-mapping/pkg/registry"
@@ -104,6 +105,9 @@ var mapCmd = &cobra.Command{
 		}
 		testObsoleter := &obsoletetests.OCPObsoleteTestManager{}
 		testIdentifier := components.NewTestIdentifier(componentRegistry, jiraComponentIDs)
+		if f.matchMetrics {
+			componentconfig.MatchMetrics.Enable()
+		}
 		var newTestMappings []v1.TestOwnership
 		var matched, unmatched int
 		success := true
@@ -140,6 +144,18 @@ var mapCmd = &cobra.Command{
 			"unmatched": unmatched,
 		}).Infof("mapping tests to ownership complete in %v", time.Since(now))
 
+		if f.matchMetrics {
+			componentconfig.MatchMetrics.Disable()
+			snapshot := componentconfig.MatchMetrics.Snapshot()
+			for stage := componentconfig.MatchStage(0); stage < componentconfig.NumMatchStages; stage++ {
+				metrics := snapshot[stage]
+				log.WithFields(log.Fields{
+					"calls":    metrics.Calls,
+					"duration": metrics.Duration,
+				}).Infof("match stage %s", stage)
+			}
+		}
+
 		variantMappings := []v1.VariantMapping{}
 		if f.mapVariants {
 			now = time.Now()
@@ -189,6 +205,7 @@ type MapFlags struct {
 	testMappingTable    string
 	variantMappingTable string
 	mapVariants         bool
+	matchMetrics        bool
 }
 
 var f = NewMapFlags()
@@ -212,6 +229,7 @@ func init() {
 	mapCmd.PersistentFlags().StringVar(&f.mode, "mode", "local", "Mode (one of: local, bigquery). Local mode doesn't require access to BigQuery and is suitable for local development.")
 	mapCmd.PersistentFlags().BoolVar(&f.pushToBQ, "push-to-bigquery", false, "whether or not to push the updated records to bigquery")
 	mapCmd.PersistentFlags().BoolVar(&f.mapVariants, "map-variant", false, "whether or not to map variants to jira projects and components")
+	mapCmd.PersistentFlags().BoolVar(&f.matchMetrics, "match-metrics", false, "whether or not to log the time spent in each stage of matching tests to components")
 	f.BindFlags(mapCmd.Flags())
 	rootCmd.AddCommand(mapCmd)
 }
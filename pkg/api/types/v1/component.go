@@ -31,11 +31,29 @@ type Component interface {
 	ListNamespaces() []string
 }
 
-// TestInfo is the input to the component owners with metadata about a test. It currently includes only
-// the test name and suite, but could in the future contain additional metadata.
+// TestInfo is the input to the component owners with metadata about a test. It currently includes
+// the test name, suite, variants and repository, but could in the future contain additional metadata.
 type TestInfo struct {
 	Name  string
 	Suite string
+
+	// Variants is the list of variants the test ran on, formatted as variantCategory:variantValue,
+	// e.g. platform:aws. It may be empty when the variants are not known.
+	Variants []string
+
+	// Repo is the org/name of the source repository the test comes from, e.g. openshift/origin.
+	// It's empty when the repository is not known, in which case matchers requiring a
+	// repository don't match the test.
+	Repo string
+
+	// Path is the path of the test's source file in its repository, e.g.
+	// test/e2e/storage/csi.go. It's empty when the source file is not known, in which
+	// case matchers requiring a path don't match the test.
+	Path string
+
+	// Annotations are attributes of the test relative to the rest of its corpus, e.g. that
+	// it has one of the longest names, added by config.AnnotateCorpus.
+	Annotations []string
 }
 
 const TestOwnershipAPIVersion = "v1"
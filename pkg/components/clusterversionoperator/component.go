@@ -54,7 +54,12 @@ var ClusterVersionOperatorComponent = Component{
 			},
 			{
 				// all cvo QE cases from ginkgo include "OTA cvo" and prow ci include "cluster upgrade" in junit xml
-				IncludeAny: []string{"OTA cvo", "cluster upgrade"},
+				IncludeAny: []string{"OTA cvo"},
+			},
+			{
+				IncludeAny: []string{"cluster upgrade"},
+				// Like upgrade above, let others claim their own cluster upgrade tests
+				Priority: -10,
 			},
 		},
 	},
@@ -79,11 +84,8 @@ func (c *Component) IdentifyTest(test *v1.TestInfo) (*v1.TestOwnership, error) {
 }
 
 func (c *Component) StableID(test *v1.TestInfo) string {
-	// Look up the stable name for our test in our renamed tests map.
-	if stableName, ok := c.TestRenames[test.Name]; ok {
-		return stableName
-	}
-	return test.Name
+	// Look up the stable name for our test in our renamed tests map and rules.
+	return c.CanonicalName(test.Name)
 }
 
 func (c *Component) JiraComponents() (components []string) {
@@ -77,3 +77,27 @@ func TestIdentifyTest(t *testing.T) {
 		})
 	}
 }
+
+func TestComponentsValidate(t *testing.T) {
+	for name, component := range registry.NewComponentRegistry().Components {
+		validator, ok := component.(interface{ Validate() error })
+		if !ok {
+			continue
+		}
+		if err := validator.Validate(); err != nil {
+			t.Errorf("component %q is invalid: %v", name, err)
+		}
+	}
+}
+
+func TestComponentsLint(t *testing.T) {
+	for name, component := range registry.NewComponentRegistry().Components {
+		linter, ok := component.(interface{ Lint() []string })
+		if !ok {
+			continue
+		}
+		for _, warning := range linter.Lint() {
+			t.Errorf("component %q lint warning: %s", name, warning)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"sort"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// AccuracyReport measures how well the components agree with reviewed ownership
+// decisions, see ScoreAgainstGroundTruth.
+type AccuracyReport struct {
+	// Total is the number of tests with a reviewed decision.
+	Total int
+	// Correct is the number of tests owned by the expected Jira component, Wrong the number
+	// owned by another one, and Unmatched the number no component owns.
+	Correct   int
+	Wrong     int
+	Unmatched int
+
+	// Disagreements are the wrong and unmatched tests, sorted by test name.
+	Disagreements []Disagreement
+}
+
+// Disagreement is a test whose resolved ownership differs from the reviewed decision.
+// Component and ActualJiraComponent are empty when no component owns the test.
+type Disagreement struct {
+	TestName              string
+	ExpectedJiraComponent string
+	Component             string
+	ActualJiraComponent   string
+}
+
+// Precision is the fraction of the matched tests that are owned by the expected Jira
+// component, or 0 if none are matched.
+func (r AccuracyReport) Precision() float64 {
+	if r.Correct+r.Wrong == 0 {
+		return 0
+	}
+	return float64(r.Correct) / float64(r.Correct+r.Wrong)
+}
+
+// Recall is the fraction of all the tests that are owned by the expected Jira component,
+// or 0 if there are no tests.
+func (r AccuracyReport) Recall() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return float64(r.Correct) / float64(r.Total)
+}
+
+// ScoreAgainstGroundTruth resolves the owner of every test in truth, a map of test name to
+// the Jira component reviewers decided should own it, and counts how many the components
+// agree with. Tests are resolved by name alone, see Resolve, so matchers needing a suite or
+// variants won't claim them. It's intended for tuning the configuration: a change should
+// not lower Correct.
+func ScoreAgainstGroundTruth(components []*Component, truth map[string]string) AccuracyReport {
+	report := AccuracyReport{Total: len(truth)}
+	for name, expected := range truth {
+		component, actual := resolveNames(components, &v1.TestInfo{Name: name})
+		switch {
+		case component == "":
+			report.Unmatched++
+		case actual == expected:
+			report.Correct++
+			continue
+		default:
+			report.Wrong++
+		}
+		report.Disagreements = append(report.Disagreements, Disagreement{
+			TestName:              name,
+			ExpectedJiraComponent: expected,
+			Component:             component,
+			ActualJiraComponent:   actual,
+		})
+	}
+
+	sort.Slice(report.Disagreements, func(i, j int) bool {
+		return report.Disagreements[i].TestName < report.Disagreements[j].TestName
+	})
+	return report
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScoreAgainstGroundTruth(t *testing.T) {
+	components := []*Component{
+		{
+			Name:                 "Networking",
+			DefaultJiraComponent: "Networking",
+			Matchers: []ComponentMatcher{
+				{SIG: "sig-network"},
+				{IncludeAll: []string{"ovn"}, JiraComponent: "Networking / ovn-kubernetes", Priority: 1},
+			},
+		},
+		{
+			Name:                 "Storage",
+			DefaultJiraComponent: "Storage",
+			Matchers: []ComponentMatcher{
+				{SIG: "sig-storage"},
+			},
+		},
+	}
+	truth := map[string]string{
+		"[sig-network] services should work":     "Networking",
+		"[sig-network] ovn pods should be ready": "Networking / ovn-kubernetes",
+		"[sig-network] dns should resolve":       "Networking / DNS",
+		"[sig-storage] volumes should mount":     "Storage",
+		"[sig-arch] should be owned":             "Architecture",
+	}
+
+	got := ScoreAgainstGroundTruth(components, truth)
+	want := AccuracyReport{
+		Total:     5,
+		Correct:   3,
+		Wrong:     1,
+		Unmatched: 1,
+		Disagreements: []Disagreement{
+			{
+				TestName:              "[sig-arch] should be owned",
+				ExpectedJiraComponent: "Architecture",
+			},
+			{
+				TestName:              "[sig-network] dns should resolve",
+				ExpectedJiraComponent: "Networking / DNS",
+				Component:             "Networking",
+				ActualJiraComponent:   "Networking",
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScoreAgainstGroundTruth() = %+v, want %+v", got, want)
+	}
+	if precision := got.Precision(); precision != 0.75 {
+		t.Errorf("Precision() = %v, want 0.75", precision)
+	}
+	if recall := got.Recall(); recall != 0.6 {
+		t.Errorf("Recall() = %v, want 0.6", recall)
+	}
+}
+
+func TestScoreAgainstGroundTruth_Empty(t *testing.T) {
+	got := ScoreAgainstGroundTruth(nil, nil)
+	if got.Total != 0 || got.Precision() != 0 || got.Recall() != 0 {
+		t.Errorf("ScoreAgainstGroundTruth() = %+v, want an empty report", got)
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// AnnotationLongName marks the tests with the longest names in their corpus, see
+// CorpusAnnotations.
+const AnnotationLongName = "long-name"
+
+// CorpusOptions configures the annotations CorpusAnnotations computes.
+type CorpusOptions struct {
+	// LongNames is the number of tests with the longest names to annotate with
+	// AnnotationLongName. Ties at the cut-off are broken by name, then by the order of the
+	// tests, so exactly LongNames tests are annotated when the corpus has that many.
+	LongNames int
+}
+
+// CorpusAnnotations returns the corpus-relative annotations of each test, in the same
+// order as the tests, without modifying them. Tests with no annotations have a nil entry.
+func CorpusAnnotations(tests []*v1.TestInfo, opts CorpusOptions) [][]string {
+	annotations := make([][]string, len(tests))
+
+	byLength := make([]int, len(tests))
+	for i := range byLength {
+		byLength[i] = i
+	}
+	sort.SliceStable(byLength, func(i, j int) bool {
+		a, b := tests[byLength[i]].Name, tests[byLength[j]].Name
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return a < b
+	})
+	for rank, i := range byLength {
+		if rank >= opts.LongNames {
+			break
+		}
+		annotations[i] = append(annotations[i], AnnotationLongName)
+	}
+
+	return annotations
+}
+
+// AnnotateCorpus adds each test's CorpusAnnotations to its Annotations, so matchers can
+// claim tests by their place in the corpus, which FindMatch can't otherwise see. It's meant
+// to be called once on the whole corpus before it's mapped; annotations the tests already
+// have are kept.
+func AnnotateCorpus(tests []*v1.TestInfo, opts CorpusOptions) {
+	for i, annotations := range CorpusAnnotations(tests, opts) {
+		if len(annotations) == 0 {
+			continue
+		}
+		tests[i].Annotations = sets.List(sets.New[string](tests[i].Annotations...).Insert(annotations...))
+	}
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestCorpusAnnotations(t *testing.T) {
+	tests := []*v1.TestInfo{
+		{Name: "short"},
+		{Name: "the longest test name"},
+		{Name: "bbbb medium"},
+		{Name: "aaaa medium"},
+	}
+
+	cases := []struct {
+		name      string
+		longNames int
+		want      [][]string
+	}{
+		{
+			name: "no annotations",
+			want: [][]string{nil, nil, nil, nil},
+		},
+		{
+			name:      "ties broken by name",
+			longNames: 2,
+			want:      [][]string{nil, {AnnotationLongName}, nil, {AnnotationLongName}},
+		},
+		{
+			name:      "more than the corpus",
+			longNames: 10,
+			want:      [][]string{{AnnotationLongName}, {AnnotationLongName}, {AnnotationLongName}, {AnnotationLongName}},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CorpusAnnotations(tests, CorpusOptions{LongNames: tt.longNames})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CorpusAnnotations() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	for _, test := range tests {
+		if test.Annotations != nil {
+			t.Errorf("CorpusAnnotations() modified %q", test.Name)
+		}
+	}
+}
+
+func TestAnnotateCorpus(t *testing.T) {
+	tests := []*v1.TestInfo{
+		{Name: "[sig-network] short"},
+		{Name: "[sig-network] a much longer test name", Annotations: []string{"reviewed"}},
+	}
+	AnnotateCorpus(tests, CorpusOptions{LongNames: 1})
+	if tests[0].Annotations != nil {
+		t.Errorf("Annotations = %q, want none", tests[0].Annotations)
+	}
+	if want := []string{AnnotationLongName, "reviewed"}; !reflect.DeepEqual(tests[1].Annotations, want) {
+		t.Errorf("Annotations = %q, want %q", tests[1].Annotations, want)
+	}
+
+	component := &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking",
+		Matchers: []ComponentMatcher{
+			{SIG: "sig-network", Annotations: []string{AnnotationLongName}},
+		},
+	}
+	if m := component.FindMatch(tests[0]); m != nil {
+		t.Errorf("FindMatch(%q) = %v, want nil", tests[0].Name, m)
+	}
+	if m := component.FindMatch(tests[1]); m == nil {
+		t.Errorf("FindMatch(%q) = nil, want a match", tests[1].Name)
+	}
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// Assignment is the owner Resolve selected for a test.
+type Assignment struct {
+	Test *v1.TestInfo
+
+	// Component and Matcher are the owning component and its winning matcher, or nil if
+	// no component claims the test.
+	Component *Component
+	Matcher   *ComponentMatcher
+}
+
+// ResolveAllContext resolves the owner of every test in the corpus, see Resolve. It checks
+// the context between tests, so long-running mapping jobs can be cancelled or timed out;
+// it then returns the assignments made so far along with the context's error.
+func ResolveAllContext(ctx context.Context, components []*Component, tests []*v1.TestInfo) ([]Assignment, error) {
+	assignments := make([]Assignment, 0, len(tests))
+	for _, test := range tests {
+		select {
+		case <-ctx.Done():
+			return assignments, ctx.Err()
+		default:
+		}
+
+		component, matcher := Resolve(components, test)
+		assignments = append(assignments, Assignment{
+			Test:      test,
+			Component: component,
+			Matcher:   matcher,
+		})
+	}
+	return assignments, nil
+}
+
+// MappingResult is the owner Resolve selected for a test, see MapAll. Component and
+// Matcher are nil if no component claims the test.
+type MappingResult struct {
+	Component *Component
+	Matcher   *ComponentMatcher
+}
+
+// MapAll resolves the owner of every test in the corpus, see Resolve, spreading the tests
+// across a pool of workers, or one per CPU if workers isn't positive. The results are keyed
+// by test name; when several tests share a name, the first one's result is kept.
+//
+// FindMatch only reads a component, including its compiled state, so the components may
+// be shared by the workers, but mustn't be modified or compiled while MapAll runs.
+func MapAll(components []*Component, tests []*v1.TestInfo, workers int) map[string]MappingResult {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	results := make([]MappingResult, len(tests))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				component, matcher := Resolve(components, tests[i])
+				results[i] = MappingResult{Component: component, Matcher: matcher}
+			}
+		}()
+	}
+	for i := range tests {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	mapping := make(map[string]MappingResult, len(tests))
+	for i, test := range tests {
+		if _, ok := mapping[test.Name]; !ok {
+			mapping[test.Name] = results[i]
+		}
+	}
+	return mapping
+}
+
+// RemapChanged updates a mapping returned by MapAll, or a previous RemapChanged, after the
+// named components changed, without resolving the whole corpus again. A test is only
+// resolved again if it isn't in prev, prev's owner is one of the changed components, or
+// one of the changed components, or one of their ancestors (see LinkParents), claims it
+// now; prev's result is kept for every other test. Names of removed components may be
+// listed as changed too.
+//
+// The result is the same as MapAll(all, tests, 0) as long as only the listed components
+// changed since prev was computed: a test's owner only depends on the components claiming
+// it, and a changed component that neither claims a test now nor owned it before can't have
+// changed its owner. Changes to anything else, such as SetIgnorePatterns, the namespace
+// patterns, or a component's Parent, other than by listing the old parent as changed too,
+// need a full MapAll.
+func RemapChanged(prev map[string]MappingResult, changedComponents []string, all []*Component, tests []*v1.TestInfo) map[string]MappingResult {
+	changed := make(map[string]bool, len(changedComponents))
+	for _, name := range changedComponents {
+		changed[name] = true
+	}
+	var affecting []*Component
+	seen := make(map[*Component]bool)
+	for _, c := range all {
+		if !changed[c.Name] {
+			continue
+		}
+		for ancestor := c; ancestor != nil && !seen[ancestor]; ancestor = ancestor.parent {
+			seen[ancestor] = true
+			affecting = append(affecting, ancestor)
+		}
+	}
+
+	mapping := make(map[string]MappingResult, len(tests))
+	for _, test := range tests {
+		if _, ok := mapping[test.Name]; ok {
+			continue
+		}
+		if result, ok := prev[test.Name]; ok && !remapNeeded(result, changed, affecting, test) {
+			mapping[test.Name] = result
+			continue
+		}
+		component, matcher := Resolve(all, test)
+		mapping[test.Name] = MappingResult{Component: component, Matcher: matcher}
+	}
+	return mapping
+}
+
+// remapNeeded reports whether the test's previous result may have been changed by the
+// changed components, see RemapChanged.
+func remapNeeded(prev MappingResult, changed map[string]bool, affecting []*Component, test *v1.TestInfo) bool {
+	if prev.Component != nil && changed[prev.Component.Name] {
+		return true
+	}
+	for _, c := range affecting {
+		if m, _ := c.FindMatchWithReason(test); m != nil {
+			return true
+		}
+	}
+	return false
+}
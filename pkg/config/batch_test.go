@@ -0,0 +1,228 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestResolveAllContext(t *testing.T) {
+	storage := &Component{
+		Name:     "Storage",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage"}},
+	}
+	tests := []*v1.TestInfo{
+		{Name: "[sig-storage] volumes should mount"},
+		{Name: "[sig-arch] should be unowned"},
+	}
+
+	assignments, err := ResolveAllContext(context.Background(), []*Component{storage}, tests)
+	if err != nil {
+		t.Fatalf("ResolveAllContext() returned error: %v", err)
+	}
+	if len(assignments) != len(tests) {
+		t.Fatalf("ResolveAllContext() returned %d assignments, want %d", len(assignments), len(tests))
+	}
+	if assignments[0].Test != tests[0] || assignments[0].Component != storage || assignments[0].Matcher == nil {
+		t.Errorf("ResolveAllContext() assignment 0 = %+v, want Storage", assignments[0])
+	}
+	if assignments[1].Test != tests[1] || assignments[1].Component != nil || assignments[1].Matcher != nil {
+		t.Errorf("ResolveAllContext() assignment 1 = %+v, want no owner", assignments[1])
+	}
+}
+
+func TestResolveAllContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	storage := &Component{
+		Name:     "Storage",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage"}},
+	}
+	assignments, err := ResolveAllContext(ctx, []*Component{storage}, []*v1.TestInfo{{Name: "[sig-storage] volumes should mount"}})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ResolveAllContext() error = %v, want %v", err, context.Canceled)
+	}
+	if len(assignments) != 0 {
+		t.Errorf("ResolveAllContext() returned %d assignments after cancellation, want 0", len(assignments))
+	}
+
+	if m, err := storage.FindMatchContext(ctx, &v1.TestInfo{Name: "[sig-storage] volumes should mount"}); m != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("FindMatchContext() = %v, %v, want nil, %v", m, err, context.Canceled)
+	}
+	if m, err := storage.FindMatchContext(context.Background(), &v1.TestInfo{Name: "[sig-storage] volumes should mount"}); m == nil || err != nil {
+		t.Errorf("FindMatchContext() = %v, %v, want a match", m, err)
+	}
+}
+
+func TestMapAll(t *testing.T) {
+	storage := &Component{
+		Name:     "Storage",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage"}},
+	}
+	etcd := &Component{
+		Name:     "Etcd",
+		Matchers: []ComponentMatcher{{SIG: "sig-etcd"}, {IncludeAll: []string{"etcd"}, Priority: 1}},
+	}
+	components := []*Component{storage, etcd}
+	for _, c := range components {
+		if err := c.Compile(); err != nil {
+			t.Fatalf("Compile() returned error: %v", err)
+		}
+	}
+
+	var tests []*v1.TestInfo
+	for i := 0; i < 100; i++ {
+		tests = append(tests,
+			&v1.TestInfo{Name: fmt.Sprintf("[sig-storage] volume %d should mount", i)},
+			&v1.TestInfo{Name: fmt.Sprintf("[sig-storage] volume %d should mount etcd data", i)},
+			&v1.TestInfo{Name: fmt.Sprintf("[sig-arch] test %d should be unowned", i)},
+		)
+	}
+	// A duplicate name in another suite keeps the first test's result.
+	tests = append(tests, &v1.TestInfo{Name: "[sig-storage] volume 0 should mount", Suite: "other"})
+
+	for _, workers := range []int{0, 1, 8} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			mapping := MapAll(components, tests, workers)
+			if len(mapping) != 300 {
+				t.Fatalf("MapAll() returned %d results, want 300", len(mapping))
+			}
+			for _, test := range tests {
+				wantComponent, wantMatcher := Resolve(components, test)
+				got := mapping[test.Name]
+				if got.Component != wantComponent || (got.Matcher == nil) != (wantMatcher == nil) {
+					t.Errorf("MapAll()[%q] = %+v, want %v", test.Name, got, wantComponent)
+				}
+			}
+		})
+	}
+}
+
+func TestRemapChanged(t *testing.T) {
+	storage := &Component{
+		Name:     "Storage",
+		OwnsSIG:  []string{"sig-storage"},
+		Matchers: []ComponentMatcher{{SIG: "sig-storage", IncludeAll: []string{"mount"}}},
+	}
+	csi := &Component{
+		Name:     "CSI",
+		Parent:   "Storage",
+		Matchers: []ComponentMatcher{{IncludeAll: []string{"csi"}}},
+	}
+	etcd := &Component{
+		Name: "Etcd",
+		Matchers: []ComponentMatcher{
+			{SIG: "sig-etcd"},
+			{IncludeAll: []string{"etcd"}, Priority: 1},
+			{IncludeAll: []string{"restored"}, Priority: PriorityFallback},
+		},
+	}
+	components := []*Component{storage, csi, etcd}
+	if err := LinkParents(components); err != nil {
+		t.Fatalf("LinkParents() returned error: %v", err)
+	}
+
+	var tests []*v1.TestInfo
+	for i := 0; i < 20; i++ {
+		tests = append(tests,
+			&v1.TestInfo{Name: fmt.Sprintf("[sig-storage] volume %d should mount", i)},
+			&v1.TestInfo{Name: fmt.Sprintf("[sig-storage] csi volume %d should mount etcd data", i)},
+			&v1.TestInfo{Name: fmt.Sprintf("[sig-storage] snapshot %d should be restored", i)},
+			&v1.TestInfo{Name: fmt.Sprintf("[sig-etcd] member %d should be healthy", i)},
+			&v1.TestInfo{Name: fmt.Sprintf("[sig-arch] test %d should be unowned", i)},
+		)
+	}
+	prev := MapAll(components, tests, 1)
+
+	// Each change is applied on top of the previous ones.
+	changes := []struct {
+		name    string
+		changed string
+		change  func()
+	}{
+		{name: "matcher narrowed", changed: "Etcd", change: func() {
+			etcd.Matchers[1] = ComponentMatcher{IncludeAll: []string{"etcd data"}, Priority: -1}
+		}},
+		{name: "matcher widened", changed: "CSI", change: func() {
+			// Snapshots are claimed by CSI, deferring Storage's fallback, so the Etcd
+			// fallback owns them.
+			csi.Matchers = []ComponentMatcher{{IncludeAny: []string{"snapshot"}, Priority: PriorityCatchAll}}
+		}},
+		{name: "child stops claiming", changed: "CSI", change: func() {
+			// Storage's fallback owns snapshots again.
+			csi.Matchers = []ComponentMatcher{{IncludeAll: []string{"csi", "never"}}}
+		}},
+		{name: "new test pattern", changed: "Etcd", change: func() {
+			etcd.Matchers = append(etcd.Matchers, ComponentMatcher{IncludeAll: []string{"unowned"}})
+		}},
+	}
+	for _, tt := range changes {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.change()
+			for _, c := range components {
+				if err := c.Compile(); err != nil {
+					t.Fatalf("Compile() returned error: %v", err)
+				}
+			}
+
+			got := RemapChanged(prev, []string{tt.changed}, components, tests)
+			want := MapAll(components, tests, 1)
+			if len(got) != len(want) {
+				t.Fatalf("RemapChanged() returned %d results, want %d", len(got), len(want))
+			}
+			reused := 0
+			for name, w := range want {
+				g := got[name]
+				if g.Component != w.Component || !reflect.DeepEqual(g.Matcher, w.Matcher) {
+					t.Errorf("RemapChanged()[%q] = %s, want %s", name, componentName(g.Component), componentName(w.Component))
+				}
+				if g.Matcher != nil && g.Matcher == prev[name].Matcher {
+					reused++
+				}
+			}
+			if reused == 0 {
+				t.Errorf("RemapChanged() didn't reuse any previous result")
+			}
+			prev = got
+		})
+	}
+}
+
+// componentName returns the component's name, or "<none>" for no component.
+func componentName(c *Component) string {
+	if c == nil {
+		return "<none>"
+	}
+	return c.Name
+}
+
+func BenchmarkMapAll(b *testing.B) {
+	var components []*Component
+	for i := 0; i < 10; i++ {
+		c := benchmarkComponent()
+		c.Name = fmt.Sprintf("%s %d", c.Name, i)
+		if err := c.Compile(); err != nil {
+			b.Fatalf("Compile() returned unexpected error: %+v", err)
+		}
+		components = append(components, c)
+	}
+	tests := benchmarkTests()
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MapAll(components, tests, 1)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MapAll(components, tests, runtime.GOMAXPROCS(0))
+		}
+	})
+}
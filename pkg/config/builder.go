@@ -0,0 +1,591 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// ComponentBuilder constructs a Component in code, validating it as it's built, e.g.:
+//
+//	component, err := NewComponent("Etcd").
+//		WithJira("OCPBUGS", "Etcd").
+//		WithOperators("etcd").
+//		AddMatcher(NewMatcher().WithSIG("sig-etcd")).
+//		Build()
+//
+// Errors are collected as the component is built and returned by Build.
+type ComponentBuilder struct {
+	component Component
+	errs      ValidationErrors
+}
+
+// NewComponent starts building a component with the given name.
+func NewComponent(name string) *ComponentBuilder {
+	b := &ComponentBuilder{component: Component{Name: name}}
+	if name == "" {
+		b.errs = append(b.errs, fmt.Errorf("component name must not be empty"))
+	}
+	return b
+}
+
+// WithJira sets the component's default Jira project and component.
+func (b *ComponentBuilder) WithJira(project, component string) *ComponentBuilder {
+	if component == "" {
+		b.errorf("default jira component must not be empty")
+	}
+	b.component.DefaultJiraProject = project
+	b.component.DefaultJiraComponent = component
+	return b
+}
+
+// WithParent sets the name of the component's parent, see LinkParents.
+func (b *ComponentBuilder) WithParent(parent string) *ComponentBuilder {
+	b.component.Parent = parent
+	return b
+}
+
+// WithDefaultCapabilities adds capabilities to every test the component claims.
+func (b *ComponentBuilder) WithDefaultCapabilities(capabilities ...string) *ComponentBuilder {
+	b.component.DefaultCapabilities = append(b.component.DefaultCapabilities, b.nonEmpty("default capabilities", capabilities)...)
+	return b
+}
+
+// WithAliases adds former names of the component's default Jira component, see
+// Component.Aliases.
+func (b *ComponentBuilder) WithAliases(aliases ...string) *ComponentBuilder {
+	b.component.Aliases = append(b.component.Aliases, b.nonEmpty("aliases", aliases)...)
+	return b
+}
+
+// WithExcludedJiraComponents adds Jira field values that stop a test from being claimed
+// by its Jira field, see Component.ExcludeJiraComponents.
+func (b *ComponentBuilder) WithExcludedJiraComponents(jiraComponents ...string) *ComponentBuilder {
+	b.component.ExcludeJiraComponents = append(b.component.ExcludeJiraComponents, b.nonEmpty("excluded jira components", jiraComponents)...)
+	return b
+}
+
+// WithOperators adds operators owned by the component.
+func (b *ComponentBuilder) WithOperators(operators ...string) *ComponentBuilder {
+	b.component.Operators = append(b.component.Operators, b.nonEmpty("operators", operators)...)
+	return b
+}
+
+// WithOperatorConfig adds an operator owned by the component, with capabilities added to
+// every one of its tests.
+func (b *ComponentBuilder) WithOperatorConfig(operator string, capabilities ...string) *ComponentBuilder {
+	if operator == "" {
+		b.errorf("operator config must have an operator")
+		return b
+	}
+	b.component.OperatorConfigs = append(b.component.OperatorConfigs, OperatorConfig{Name: operator, Capabilities: capabilities})
+	return b
+}
+
+// WithOperatorTestPatterns adds regular expressions recognizing the operator's tests.
+func (b *ComponentBuilder) WithOperatorTestPatterns(operator string, patterns ...string) *ComponentBuilder {
+	if operator == "" {
+		b.errorf("operator test patterns must have an operator")
+		return b
+	}
+	if b.component.OperatorTestPatterns == nil {
+		b.component.OperatorTestPatterns = make(map[string][]string)
+	}
+	b.component.OperatorTestPatterns[operator] = append(b.component.OperatorTestPatterns[operator], b.nonEmpty("operator test patterns", patterns)...)
+	return b
+}
+
+// WithNamespaces adds namespaces owned by the component.
+func (b *ComponentBuilder) WithNamespaces(namespaces ...string) *ComponentBuilder {
+	b.component.Namespaces = append(b.component.Namespaces, b.nonEmpty("namespaces", namespaces)...)
+	return b
+}
+
+// WithNamespacePriority sets the priority of the component's namespace ownership matches.
+func (b *ComponentBuilder) WithNamespacePriority(priority int) *ComponentBuilder {
+	b.component.NamespacePriority = priority
+	return b
+}
+
+// WithOwnsSIG adds SIGs owned outright by the component, claimed at the priority.
+func (b *ComponentBuilder) WithOwnsSIG(priority int, sigs ...string) *ComponentBuilder {
+	b.component.OwnsSIG = append(b.component.OwnsSIG, b.nonEmpty("owned sigs", sigs)...)
+	b.component.OwnsSIGPriority = priority
+	return b
+}
+
+// WithNamespacePatterns adds patterns used to find the namespace a test references.
+func (b *ComponentBuilder) WithNamespacePatterns(patterns ...string) *ComponentBuilder {
+	b.component.NamespacePatterns = append(b.component.NamespacePatterns, b.nonEmpty("namespace patterns", patterns)...)
+	return b
+}
+
+// WithJiraComponentRegex sets the pattern used to find the Jira component a test names, see
+// Component.JiraComponentRegex.
+func (b *ComponentBuilder) WithJiraComponentRegex(pattern string) *ComponentBuilder {
+	b.component.JiraComponentRegex = pattern
+	return b
+}
+
+// WithNormalizeNames makes the component match test names normalized by NormalizeTestName.
+func (b *ComponentBuilder) WithNormalizeNames() *ComponentBuilder {
+	b.component.NormalizeNames = true
+	return b
+}
+
+// WithSortedMatchers makes the component sort its matchers when compiled, see
+// Component.SortMatchers.
+func (b *ComponentBuilder) WithSortedMatchers() *ComponentBuilder {
+	b.component.SortMatchers = true
+	return b
+}
+
+// WithVariants adds variants, formatted as variantCategory:variantValue, owned by the
+// component.
+func (b *ComponentBuilder) WithVariants(variants ...string) *ComponentBuilder {
+	b.component.Variants = append(b.component.Variants, b.nonEmpty("variants", variants)...)
+	return b
+}
+
+// WithTestRename records that the test currently named from was previously named to.
+func (b *ComponentBuilder) WithTestRename(from, to string) *ComponentBuilder {
+	if from == "" || to == "" {
+		b.errorf("test rename %q to %q must have both names", from, to)
+		return b
+	}
+	if existing, ok := b.component.TestRenames[from]; ok && existing != to {
+		b.errorf("test %q is already renamed to %q", from, existing)
+		return b
+	}
+	if b.component.TestRenames == nil {
+		b.component.TestRenames = make(map[string]string)
+	}
+	b.component.TestRenames[from] = to
+	return b
+}
+
+// WithTestRenameRule adds a regular expression rename rule.
+func (b *ComponentBuilder) WithTestRenameRule(pattern, replacement string) *ComponentBuilder {
+	if pattern == "" {
+		b.errorf("test rename rule pattern must not be empty")
+		return b
+	}
+	b.component.TestRenameRules = append(b.component.TestRenameRules, RenameRule{Pattern: pattern, Replacement: replacement})
+	return b
+}
+
+// AddMatcher adds a matcher to the component.
+func (b *ComponentBuilder) AddMatcher(m *MatcherBuilder) *ComponentBuilder {
+	index := len(b.component.Matchers)
+	for _, err := range m.errs {
+		b.errorf("matcher %d: %w", index, err)
+	}
+	// The conditions are checked along with those the matcher takes from its template. An
+	// unknown template is left for Build to report.
+	if resolved, err := m.matcher.resolveTemplate(); err == nil && resolved.Specificity() == 0 {
+		b.errorf("matcher %d: must have at least one condition", index)
+	}
+	b.component.Matchers = append(b.component.Matchers, m.matcher)
+	return b
+}
+
+// AddCustomMatcher adds a procedural matcher to the component, see
+// Component.CustomMatchers.
+func (b *ComponentBuilder) AddCustomMatcher(matcher func(*v1.TestInfo) *ComponentMatcher) *ComponentBuilder {
+	if matcher == nil {
+		b.errorf("custom matcher %d must not be nil", len(b.component.CustomMatchers))
+		return b
+	}
+	b.component.CustomMatchers = append(b.component.CustomMatchers, matcher)
+	return b
+}
+
+// Build validates and compiles the component, returning every problem found while
+// building it.
+func (b *ComponentBuilder) Build() (*Component, error) {
+	errs := append(ValidationErrors{}, b.errs...)
+
+	component := b.component
+	var validationErrs ValidationErrors
+	if err := component.Validate(); errors.As(err, &validationErrs) {
+		errs = append(errs, validationErrs...)
+	} else if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if err := component.Compile(); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+func (b *ComponentBuilder) errorf(format string, args ...interface{}) {
+	b.errs = append(b.errs, fmt.Errorf("component %q: "+format, append([]interface{}{b.component.Name}, args...)...))
+}
+
+func (b *ComponentBuilder) nonEmpty(field string, values []string) []string {
+	if err := checkNonEmpty(field, values); err != nil {
+		b.errorf("%w", err)
+	}
+	return values
+}
+
+// MatcherBuilder constructs a ComponentMatcher for a ComponentBuilder.
+type MatcherBuilder struct {
+	matcher ComponentMatcher
+	errs    []error
+}
+
+// NewMatcher starts building a matcher.
+func NewMatcher() *MatcherBuilder {
+	return &MatcherBuilder{}
+}
+
+// WithSIG requires the test to belong to the SIG.
+func (m *MatcherBuilder) WithSIG(sig string) *MatcherBuilder {
+	if sig == "" {
+		m.errs = append(m.errs, fmt.Errorf("sig must not be empty"))
+	}
+	m.matcher.SIG = sig
+	return m
+}
+
+// WithSIGAny requires the test to belong to any of the SIGs.
+func (m *MatcherBuilder) WithSIGAny(sigs ...string) *MatcherBuilder {
+	m.matcher.SIGAny = append(m.matcher.SIGAny, m.nonEmpty("sigAny", sigs)...)
+	return m
+}
+
+// WithExcludeSIG rejects tests belonging to any of the SIGs.
+func (m *MatcherBuilder) WithExcludeSIG(sigs ...string) *MatcherBuilder {
+	m.matcher.ExcludeSIG = append(m.matcher.ExcludeSIG, m.nonEmpty("excludeSIG", sigs)...)
+	return m
+}
+
+// WithSuite requires the test to be in the suite.
+func (m *MatcherBuilder) WithSuite(suite string) *MatcherBuilder {
+	if suite == "" {
+		m.errs = append(m.errs, fmt.Errorf("suite must not be empty"))
+	}
+	m.matcher.Suite = suite
+	return m
+}
+
+// WithSuiteAny requires the test to be in any of the suites.
+func (m *MatcherBuilder) WithSuiteAny(suites ...string) *MatcherBuilder {
+	m.matcher.SuiteAny = append(m.matcher.SuiteAny, m.nonEmpty("suiteAny", suites)...)
+	return m
+}
+
+// WithSuitePrefix requires the test's suite to begin with the prefix.
+func (m *MatcherBuilder) WithSuitePrefix(prefix string) *MatcherBuilder {
+	if prefix == "" {
+		m.errs = append(m.errs, fmt.Errorf("suitePrefix must not be empty"))
+	}
+	m.matcher.SuitePrefix = prefix
+	return m
+}
+
+// WithExcludeSuite rejects tests in any of the suites.
+func (m *MatcherBuilder) WithExcludeSuite(suites ...string) *MatcherBuilder {
+	m.matcher.ExcludeSuite = append(m.matcher.ExcludeSuite, m.nonEmpty("excludeSuite", suites)...)
+	return m
+}
+
+// WithVariants requires the test to have run on all of the variants.
+func (m *MatcherBuilder) WithVariants(variants ...string) *MatcherBuilder {
+	m.matcher.Variants = append(m.matcher.Variants, m.nonEmpty("variants", variants)...)
+	return m
+}
+
+// WithRepo requires the test to come from the repository, formatted as org/name.
+func (m *MatcherBuilder) WithRepo(repo string) *MatcherBuilder {
+	if repo == "" {
+		m.errs = append(m.errs, fmt.Errorf("repo must not be empty"))
+	}
+	m.matcher.Repo = repo
+	return m
+}
+
+// WithRepoAny requires the test to come from any of the repositories.
+func (m *MatcherBuilder) WithRepoAny(repos ...string) *MatcherBuilder {
+	m.matcher.RepoAny = append(m.matcher.RepoAny, m.nonEmpty("repoAny", repos)...)
+	return m
+}
+
+// WithTag requires the test name to carry the tag key, with all of the values. Without
+// values, the tag is a flag such as [Disruptive].
+func (m *MatcherBuilder) WithTag(key string, values ...string) *MatcherBuilder {
+	if key == "" {
+		m.errs = append(m.errs, fmt.Errorf("tag must not be empty"))
+	}
+	if len(values) > 0 {
+		m.nonEmpty("tag values", values)
+	}
+	if m.matcher.Tags == nil {
+		m.matcher.Tags = make(map[string][]string)
+	}
+	m.matcher.Tags[key] = append(m.matcher.Tags[key], values...)
+	return m
+}
+
+// WithParam requires the test name to carry the bracketed key=value parameter, see
+// ComponentMatcher.Params.
+func (m *MatcherBuilder) WithParam(key, value string) *MatcherBuilder {
+	if key == "" {
+		m.errs = append(m.errs, fmt.Errorf("param must not be empty"))
+	}
+	if m.matcher.Params == nil {
+		m.matcher.Params = make(map[string]string)
+	}
+	m.matcher.Params[key] = value
+	return m
+}
+
+// WithRequireFlags requires the test name to carry all of the bracket flags.
+func (m *MatcherBuilder) WithRequireFlags(flags ...string) *MatcherBuilder {
+	m.matcher.RequireFlags = append(m.matcher.RequireFlags, m.nonEmpty("requireFlags", flags)...)
+	return m
+}
+
+// WithAnnotations requires the test to carry all of the corpus annotations, see
+// AnnotateCorpus.
+func (m *MatcherBuilder) WithAnnotations(annotations ...string) *MatcherBuilder {
+	m.matcher.Annotations = append(m.matcher.Annotations, m.nonEmpty("annotations", annotations)...)
+	return m
+}
+
+// WithNamePrefix requires the test name to begin with prefix.
+func (m *MatcherBuilder) WithNamePrefix(prefix string) *MatcherBuilder {
+	if prefix == "" {
+		m.errs = append(m.errs, fmt.Errorf("namePrefix must not be empty"))
+	}
+	m.matcher.NamePrefix = prefix
+	return m
+}
+
+// WithNameSuffix requires the test name to end with suffix.
+func (m *MatcherBuilder) WithNameSuffix(suffix string) *MatcherBuilder {
+	if suffix == "" {
+		m.errs = append(m.errs, fmt.Errorf("nameSuffix must not be empty"))
+	}
+	m.matcher.NameSuffix = suffix
+	return m
+}
+
+// WithTopLevelContains requires all of the substrings to be in the first segment of the
+// test name's hierarchy.
+func (m *MatcherBuilder) WithTopLevelContains(substrings ...string) *MatcherBuilder {
+	m.matcher.TopLevelContains = append(m.matcher.TopLevelContains, m.nonEmpty("topLevelContains", substrings)...)
+	return m
+}
+
+// WithNoSIG requires the test name to carry no SIG tag.
+func (m *MatcherBuilder) WithNoSIG() *MatcherBuilder {
+	m.matcher.NoSIG = true
+	return m
+}
+
+// WithSerial requires the test to run serially, or in parallel if serial is false.
+func (m *MatcherBuilder) WithSerial(serial bool) *MatcherBuilder {
+	m.matcher.Serial = &serial
+	return m
+}
+
+// WithIsDisruption requires the test to be a disruption test.
+func (m *MatcherBuilder) WithIsDisruption() *MatcherBuilder {
+	m.matcher.IsDisruption = true
+	return m
+}
+
+// WithIncludeAll requires all of the substrings to be in the test name.
+func (m *MatcherBuilder) WithIncludeAll(substrings ...string) *MatcherBuilder {
+	m.matcher.IncludeAll = append(m.matcher.IncludeAll, m.nonEmpty("includeAll", substrings)...)
+	return m
+}
+
+// WithSuiteIncludeAll requires all of the substrings to be in the test's suite.
+func (m *MatcherBuilder) WithSuiteIncludeAll(substrings ...string) *MatcherBuilder {
+	m.matcher.SuiteIncludeAll = append(m.matcher.SuiteIncludeAll, m.nonEmpty("suiteIncludeAll", substrings)...)
+	return m
+}
+
+// WithSuiteIncludeAny requires any of the substrings to be in the test's suite.
+func (m *MatcherBuilder) WithSuiteIncludeAny(substrings ...string) *MatcherBuilder {
+	m.matcher.SuiteIncludeAny = append(m.matcher.SuiteIncludeAny, m.nonEmpty("suiteIncludeAny", substrings)...)
+	return m
+}
+
+// WithIncludeAny requires any of the substrings to be in the test name.
+func (m *MatcherBuilder) WithIncludeAny(substrings ...string) *MatcherBuilder {
+	m.matcher.IncludeAny = append(m.matcher.IncludeAny, m.nonEmpty("includeAny", substrings)...)
+	return m
+}
+
+// WithIncludeNone requires none of the substrings to be in the test name.
+func (m *MatcherBuilder) WithIncludeNone(substrings ...string) *MatcherBuilder {
+	m.matcher.IncludeNone = append(m.matcher.IncludeNone, m.nonEmpty("includeNone", substrings)...)
+	return m
+}
+
+// WithExcludeAll rejects tests whose name contains all of the substrings.
+func (m *MatcherBuilder) WithExcludeAll(substrings ...string) *MatcherBuilder {
+	m.matcher.ExcludeAll = append(m.matcher.ExcludeAll, m.nonEmpty("excludeAll", substrings)...)
+	return m
+}
+
+// WithExcludeAny rejects tests whose name contains any of the substrings.
+func (m *MatcherBuilder) WithExcludeAny(substrings ...string) *MatcherBuilder {
+	m.matcher.ExcludeAny = append(m.matcher.ExcludeAny, m.nonEmpty("excludeAny", substrings)...)
+	return m
+}
+
+// WithIncludeWord requires all of the words to be in the test name as whole words.
+func (m *MatcherBuilder) WithIncludeWord(words ...string) *MatcherBuilder {
+	m.matcher.IncludeWord = append(m.matcher.IncludeWord, m.nonEmpty("includeWord", words)...)
+	return m
+}
+
+// WithExcludeWord rejects tests whose name contains any of the words as a whole word.
+func (m *MatcherBuilder) WithExcludeWord(words ...string) *MatcherBuilder {
+	m.matcher.ExcludeWord = append(m.matcher.ExcludeWord, m.nonEmpty("excludeWord", words)...)
+	return m
+}
+
+// WithIncludeRegex requires all of the regular expressions to match the test name.
+func (m *MatcherBuilder) WithIncludeRegex(patterns ...string) *MatcherBuilder {
+	m.matcher.IncludeRegex = append(m.matcher.IncludeRegex, m.nonEmpty("includeRegex", patterns)...)
+	return m
+}
+
+// WithNameMatchesRegex requires the whole test name to match the regular expression, see
+// ComponentMatcher.NameMatchesRegex.
+func (m *MatcherBuilder) WithNameMatchesRegex(pattern string) *MatcherBuilder {
+	m.matcher.NameMatchesRegex = pattern
+	return m
+}
+
+// WithMatchCleanName compares IncludeAll and IncludeAny to the test name without its tags.
+func (m *MatcherBuilder) WithMatchCleanName() *MatcherBuilder {
+	m.matcher.MatchCleanName = true
+	return m
+}
+
+// WithNumber requires the number captured from the test name by the pattern's Number
+// group to meet the threshold, e.g. ">= 50".
+func (m *MatcherBuilder) WithNumber(pattern, threshold string) *MatcherBuilder {
+	m.matcher.Number = &NumberCondition{Pattern: pattern, Threshold: threshold}
+	return m
+}
+
+// WithNegate inverts the matcher, see ComponentMatcher.Negate.
+func (m *MatcherBuilder) WithNegate() *MatcherBuilder {
+	m.matcher.Negate = true
+	return m
+}
+
+// WithExpr replaces the matcher's field-based conditions with the boolean expression, see
+// ComponentMatcher.Expr.
+func (m *MatcherBuilder) WithExpr(expr string) *MatcherBuilder {
+	m.matcher.Expr = expr
+	return m
+}
+
+// WithPathGlob requires any of the doublestar-style glob patterns to match the test's
+// source file path.
+func (m *MatcherBuilder) WithPathGlob(patterns ...string) *MatcherBuilder {
+	m.matcher.PathGlob = append(m.matcher.PathGlob, m.nonEmpty("pathGlob", patterns)...)
+	return m
+}
+
+// WithIncludeGlob requires all of the glob patterns to match the test name.
+func (m *MatcherBuilder) WithIncludeGlob(patterns ...string) *MatcherBuilder {
+	m.matcher.IncludeGlob = append(m.matcher.IncludeGlob, m.nonEmpty("includeGlob", patterns)...)
+	return m
+}
+
+// WithExcludeGlob rejects tests whose name matches any of the glob patterns.
+func (m *MatcherBuilder) WithExcludeGlob(patterns ...string) *MatcherBuilder {
+	m.matcher.ExcludeGlob = append(m.matcher.ExcludeGlob, m.nonEmpty("excludeGlob", patterns)...)
+	return m
+}
+
+// CaseInsensitive makes the matcher's substring comparisons ignore case.
+func (m *MatcherBuilder) CaseInsensitive() *MatcherBuilder {
+	m.matcher.CaseInsensitive = true
+	return m
+}
+
+// WithJiraProject overrides the component's default Jira project for matching tests.
+func (m *MatcherBuilder) WithJiraProject(project string) *MatcherBuilder {
+	m.matcher.JiraProject = project
+	return m
+}
+
+// WithJiraComponent overrides the component's default Jira component for matching tests.
+func (m *MatcherBuilder) WithJiraComponent(component string) *MatcherBuilder {
+	m.matcher.JiraComponent = component
+	return m
+}
+
+// WithCapabilities adds capabilities to matching tests.
+func (m *MatcherBuilder) WithCapabilities(capabilities ...string) *MatcherBuilder {
+	m.matcher.Capabilities = append(m.matcher.Capabilities, m.nonEmpty("capabilities", capabilities)...)
+	return m
+}
+
+// WithPriority sets the matcher's priority.
+func (m *MatcherBuilder) WithPriority(priority int) *MatcherBuilder {
+	m.matcher.Priority = priority
+	return m
+}
+
+// WithEffectiveRange limits the matcher to the releases from the first up to, but not
+// including, the second, see ComponentMatcher.EffectiveFrom. Either may be empty.
+func (m *MatcherBuilder) WithEffectiveRange(from, until string) *MatcherBuilder {
+	m.matcher.EffectiveFrom = from
+	m.matcher.EffectiveUntil = until
+	return m
+}
+
+// WithTemplate merges the named matcher template into the matcher when its component is
+// compiled, see ComponentMatcher.TemplateRef.
+func (m *MatcherBuilder) WithTemplate(name string) *MatcherBuilder {
+	if name == "" {
+		m.errs = append(m.errs, fmt.Errorf("template name must not be empty"))
+	}
+	m.matcher.TemplateRef = name
+	return m
+}
+
+// Matcher returns the matcher built so far, and any errors found building it.
+func (m *MatcherBuilder) Matcher() (ComponentMatcher, error) {
+	if len(m.errs) > 0 {
+		return m.matcher, ValidationErrors(m.errs)
+	}
+	return m.matcher, nil
+}
+
+func (m *MatcherBuilder) nonEmpty(field string, values []string) []string {
+	if err := checkNonEmpty(field, values); err != nil {
+		m.errs = append(m.errs, err)
+	}
+	return values
+}
+
+// checkNonEmpty returns an error if the list, or any of its values, are empty.
+func checkNonEmpty(field string, values []string) error {
+	if len(values) == 0 {
+		return fmt.Errorf("%s must not be empty", field)
+	}
+	for _, v := range values {
+		if v == "" {
+			return fmt.Errorf("%s must not contain empty values", field)
+		}
+	}
+	return nil
+}
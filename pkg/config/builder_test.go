@@ -0,0 +1,106 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestComponentBuilder(t *testing.T) {
+	component, err := NewComponent("Etcd").
+		WithJira("OCPBUGS", "Etcd").
+		WithOperators("etcd").
+		WithNamespaces("openshift-etcd", "openshift-etcd-operator").
+		WithVariants("platform:aws").
+		WithTestRename("[sig-etcd] new name", "[sig-etcd] old name").
+		AddMatcher(NewMatcher().WithSIG("sig-etcd")).
+		AddMatcher(NewMatcher().WithIncludeAny(":Etcd ").WithPriority(1)).
+		AddMatcher(NewMatcher().WithSuiteAny("DR_Testing", "ETCD").WithCapabilities("DR")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() returned unexpected error: %v", err)
+	}
+
+	want := &Component{
+		Name:                 "Etcd",
+		DefaultJiraProject:   "OCPBUGS",
+		DefaultJiraComponent: "Etcd",
+		Operators:            []string{"etcd"},
+		Namespaces:           []string{"openshift-etcd", "openshift-etcd-operator"},
+		Variants:             []string{"platform:aws"},
+		TestRenames:          map[string]string{"[sig-etcd] new name": "[sig-etcd] old name"},
+		Matchers: []ComponentMatcher{
+			{SIG: "sig-etcd"},
+			{IncludeAny: []string{":Etcd "}, Priority: 1},
+			{SuiteAny: []string{"DR_Testing", "ETCD"}, Capabilities: []string{"DR"}},
+		},
+	}
+	if err := want.Compile(); err != nil {
+		t.Fatalf("Compile() returned unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(component, want) {
+		t.Errorf("Build() = %+v, want %+v", component, want)
+	}
+
+	if m := component.FindMatch(&v1.TestInfo{Name: "backup", Suite: "DR_Testing"}); m == nil || !reflect.DeepEqual(m.Capabilities, []string{"DR"}) {
+		t.Errorf("FindMatch() = %+v, want the DR_Testing matcher", m)
+	}
+}
+
+func TestComponentBuilder_TemplateOnlyMatcher(t *testing.T) {
+	MatcherTemplates["etcd"] = ComponentMatcher{SIG: "sig-etcd"}
+	defer delete(MatcherTemplates, "etcd")
+
+	component, err := NewComponent("Etcd").
+		WithJira("OCPBUGS", "Etcd").
+		AddMatcher(NewMatcher().WithTemplate("etcd")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() returned unexpected error: %v", err)
+	}
+	if m := component.FindMatch(&v1.TestInfo{Name: "[sig-etcd] should be healthy"}); m == nil {
+		t.Errorf("FindMatch() = nil, want the template's matcher")
+	}
+
+	MatcherTemplates["empty"] = ComponentMatcher{JiraComponent: "Etcd"}
+	defer delete(MatcherTemplates, "empty")
+	_, err = NewComponent("Etcd").
+		WithJira("OCPBUGS", "Etcd").
+		AddMatcher(NewMatcher().WithTemplate("empty")).
+		Build()
+	if want := `component "Etcd": matcher 0: must have at least one condition`; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Build() error = %v, want %q", err, want)
+	}
+}
+
+func TestComponentBuilder_Errors(t *testing.T) {
+	_, err := NewComponent("Etcd").
+		WithJira("OCPBUGS", "").
+		WithNamespaces().
+		WithTestRename("[sig-etcd] new name", "[sig-etcd] old name").
+		WithTestRename("[sig-etcd] new name", "[sig-etcd] older name").
+		AddMatcher(NewMatcher().WithIncludeAll()).
+		AddMatcher(NewMatcher().WithIncludeAny("etcd", "")).
+		AddMatcher(NewMatcher()).
+		AddMatcher(NewMatcher().WithIncludeGlob("*[etcd")).
+		Build()
+	if err == nil {
+		t.Fatalf("Build() did not return an error")
+	}
+
+	for _, want := range []string{
+		`component "Etcd": default jira component must not be empty`,
+		`component "Etcd": namespaces must not be empty`,
+		`component "Etcd": test "[sig-etcd] new name" is already renamed to "[sig-etcd] old name"`,
+		`component "Etcd": matcher 0: includeAll must not be empty`,
+		`component "Etcd": matcher 1: includeAny must not contain empty values`,
+		`component "Etcd": matcher 2: must have at least one condition`,
+		`component "Etcd" matchers[3]: glob "*[etcd": unterminated character class`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Build() error %q does not contain %q", err.Error(), want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// MatchCacheSize is the number of tests each compiled component remembers the match of,
+// so evaluating a test against it again doesn't repeat FindMatch's stages. The least
+// recently used entries are evicted first. It's 0 by default, disabling the cache, as the
+// registry evaluates each test once per component; it's read by Compile, so set it before
+// compiling the components for runs that evaluate tests again, e.g. RemapChanged after
+// MapAll, or reports over a mapped corpus. Components that haven't been compiled, or have
+// CustomMatchers, whose funcs needn't be pure, never cache. Changing the package-wide
+// settings matches depend on, with SetNormalizedTrailingTags or RegisterNamespacePattern,
+// discards every cached match. Cache hits skip the stages, so MatchMetrics doesn't count
+// them.
+var MatchCacheSize int
+
+// matchCacheGeneration counts changes to the package-wide settings matches depend on, so
+// caches can discard the matches made under the previous settings, see
+// invalidateMatchCaches.
+var matchCacheGeneration atomic.Uint64
+
+// invalidateMatchCaches discards the matches cached by every component. It must be called
+// after changing any package-wide setting that affects FindMatch.
+func invalidateMatchCaches() {
+	matchCacheGeneration.Add(1)
+}
+
+// matchCacheKey identifies a test by everything matchers may depend on: its name and
+// suite, as well as its variants, repo, path and annotations.
+type matchCacheKey struct {
+	name        string
+	suite       string
+	variants    string
+	repo        string
+	path        string
+	annotations string
+}
+
+func newMatchCacheKey(test *v1.TestInfo) matchCacheKey {
+	return matchCacheKey{
+		name:        test.Name,
+		suite:       test.Suite,
+		variants:    strings.Join(test.Variants, "\x00"),
+		repo:        test.Repo,
+		path:        test.Path,
+		annotations: strings.Join(test.Annotations, "\x00"),
+	}
+}
+
+type matchCacheEntry struct {
+	key     matchCacheKey
+	matcher *ComponentMatcher
+	reason  MatchReason
+}
+
+// matchCache is an LRU cache of a compiled component's matches. It's safe for concurrent
+// use, e.g. by MapAll's workers; a new one is made each time the component is compiled.
+type matchCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[matchCacheKey]*list.Element
+	order   *list.List
+
+	// generation is the matchCacheGeneration the entries were made in.
+	generation uint64
+}
+
+// newMatchCache returns a cache holding up to size matches, or nil if size isn't positive.
+func newMatchCache(size int) *matchCache {
+	if size <= 0 {
+		return nil
+	}
+	return &matchCache{
+		size:       size,
+		entries:    make(map[matchCacheKey]*list.Element, size),
+		order:      list.New(),
+		generation: matchCacheGeneration.Load(),
+	}
+}
+
+// get returns the cached match of the test, and whether there is one. Either way, it also
+// returns the generation to pass to add, so a match computed under settings that have since
+// changed isn't cached.
+func (mc *matchCache) get(key matchCacheKey) (*ComponentMatcher, MatchReason, uint64, bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.syncGeneration()
+	element, ok := mc.entries[key]
+	if !ok {
+		return nil, MatchReason{}, mc.generation, false
+	}
+	mc.order.MoveToFront(element)
+	entry := element.Value.(*matchCacheEntry)
+	matcher, reason := cloneMatch(entry.matcher, entry.reason)
+	return matcher, reason, mc.generation, true
+}
+
+func (mc *matchCache) add(key matchCacheKey, generation uint64, matcher *ComponentMatcher, reason MatchReason) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.syncGeneration(); generation != mc.generation {
+		return
+	}
+	if element, ok := mc.entries[key]; ok {
+		mc.order.MoveToFront(element)
+		return
+	}
+	matcher, reason = cloneMatch(matcher, reason)
+	mc.entries[key] = mc.order.PushFront(&matchCacheEntry{key: key, matcher: matcher, reason: reason})
+	if mc.order.Len() > mc.size {
+		oldest := mc.order.Back()
+		mc.order.Remove(oldest)
+		delete(mc.entries, oldest.Value.(*matchCacheEntry).key)
+	}
+}
+
+// cloneMatch copies the match, so callers changing the matcher's or reason's fields, e.g.
+// Priority or Capabilities, don't change what the cache returns to others, as FindMatch
+// returns a new matcher on every call otherwise.
+func cloneMatch(matcher *ComponentMatcher, reason MatchReason) (*ComponentMatcher, MatchReason) {
+	if matcher != nil {
+		clone := *matcher
+		clone.Capabilities = cloneStrings(matcher.Capabilities)
+		matcher = &clone
+	}
+	reason.SIGs = cloneStrings(reason.SIGs)
+	reason.Variants = cloneStrings(reason.Variants)
+	reason.Substrings = cloneStrings(reason.Substrings)
+	return matcher, reason
+}
+
+func cloneStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append([]string{}, values...)
+}
+
+// syncGeneration discards the entries if the package-wide settings have changed since they
+// were made. It must be called with mu held.
+func (mc *matchCache) syncGeneration() {
+	if generation := matchCacheGeneration.Load(); generation != mc.generation {
+		mc.entries = make(map[matchCacheKey]*list.Element, mc.size)
+		mc.order.Init()
+		mc.generation = generation
+	}
+}
+
+func (mc *matchCache) len() int {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	return mc.order.Len()
+}
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// enableMatchCache sets MatchCacheSize for the components compiled next, and enables
+// MatchMetrics so evaluations can tell whether FindMatch was served from the cache. The
+// returned func restores the defaults.
+func enableMatchCache(size int) func() {
+	previous := MatchCacheSize
+	MatchCacheSize = size
+	MatchMetrics.Reset()
+	MatchMetrics.Enable()
+	return func() {
+		MatchCacheSize = previous
+		MatchMetrics.Disable()
+		MatchMetrics.Reset()
+	}
+}
+
+// evaluations returns the number of times FindMatch evaluated a test rather than returning
+// a cached match, since enableMatchCache.
+func evaluations() int64 {
+	return MatchMetrics.Snapshot()[MatchStageJira].Calls
+}
+
+// suiteComponent returns a component claiming the tests in the openshift-tests suite.
+func suiteComponent() *Component {
+	return &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking",
+		Matchers: []ComponentMatcher{
+			{Suite: "openshift-tests", JiraComponent: "Networking"},
+		},
+	}
+}
+
+func TestComponent_MatchCache(t *testing.T) {
+	defer enableMatchCache(4096)()
+	component := suiteComponent()
+
+	test := &v1.TestInfo{Name: "pods should be ready", Suite: "openshift-tests"}
+	component.FindMatch(test)
+	component.FindMatch(test)
+	if got := evaluations(); got != 2 {
+		t.Fatalf("uncompiled component made %d evaluations, want 2", got)
+	}
+
+	if err := component.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	MatchMetrics.Reset()
+	first := component.FindMatch(test)
+	second := component.FindMatch(test)
+	if first == nil || !reflect.DeepEqual(first, second) {
+		t.Errorf("FindMatch() = %v, then %v, want the same cached match", first, second)
+	}
+	if got := evaluations(); got != 1 {
+		t.Errorf("compiled component made %d evaluations, want 1", got)
+	}
+	if first == nil || first == second {
+		t.Errorf("FindMatch() returned the same matcher twice, want a copy from the cache")
+	}
+	first.Priority = PriorityForce
+	first.Capabilities = append(first.Capabilities, "changed")
+	if third := component.FindMatch(test); third.Priority != PriorityDefault || len(third.Capabilities) != 0 {
+		t.Errorf("FindMatch() = %+v after changing an earlier result, want the cached match unchanged", third)
+	}
+
+	if m := component.FindMatch(&v1.TestInfo{Name: test.Name, Suite: "other"}); m != nil {
+		t.Errorf("FindMatch() for another suite = %v, want nil", m)
+	}
+	if got := evaluations(); got != 2 {
+		t.Errorf("a test in another suite made %d evaluations, want 2", got)
+	}
+
+	if m := component.FindMatchForRelease(test, "4.14"); m == nil {
+		t.Errorf("FindMatchForRelease() = nil, want a match")
+	}
+	if got := evaluations(); got != 3 {
+		t.Errorf("a release match made %d evaluations, want 3, releases aren't cached", got)
+	}
+
+	if err := component.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	component.FindMatch(test)
+	if got := evaluations(); got != 4 {
+		t.Errorf("recompiled component made %d evaluations, want 4", got)
+	}
+}
+
+func TestComponent_MatchCacheEviction(t *testing.T) {
+	defer enableMatchCache(2)()
+	component := suiteComponent()
+	if err := component.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	a := &v1.TestInfo{Name: "a", Suite: "openshift-tests"}
+	b := &v1.TestInfo{Name: "b", Suite: "openshift-tests"}
+	c := &v1.TestInfo{Name: "c", Suite: "openshift-tests"}
+	for _, test := range []*v1.TestInfo{a, b, a, c, a} {
+		component.FindMatch(test)
+	}
+	// b was the least recently used test when c was added, so only b is recomputed.
+	if got := evaluations(); got != 3 {
+		t.Errorf("made %d evaluations, want 3", got)
+	}
+	component.FindMatch(b)
+	if got := evaluations(); got != 4 {
+		t.Errorf("evicted test made %d evaluations, want 4", got)
+	}
+	if got := component.matchCache.len(); got != 2 {
+		t.Errorf("cache holds %d matches, want 2", got)
+	}
+}
+
+func TestComponent_MatchCacheDisabled(t *testing.T) {
+	defer enableMatchCache(0)()
+	component := suiteComponent()
+	if err := component.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	test := &v1.TestInfo{Name: "pods should be ready", Suite: "openshift-tests"}
+	component.FindMatch(test)
+	component.FindMatch(test)
+	if got := evaluations(); got != 2 {
+		t.Errorf("made %d evaluations, want 2 with the cache disabled", got)
+	}
+	if component.matchCache != nil {
+		t.Errorf("component has a match cache, want none with MatchCacheSize 0")
+	}
+}
+
+func TestComponent_MatchCacheCustomMatchers(t *testing.T) {
+	defer enableMatchCache(4096)()
+
+	var calls int
+	component := &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking",
+		CustomMatchers: []func(*v1.TestInfo) *ComponentMatcher{
+			func(test *v1.TestInfo) *ComponentMatcher {
+				calls++
+				return &ComponentMatcher{JiraComponent: "Networking"}
+			},
+		},
+	}
+	if err := component.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	test := &v1.TestInfo{Name: "pods should be ready", Suite: "openshift-tests"}
+	component.FindMatch(test)
+	component.FindMatch(test)
+	if calls != 2 {
+		t.Errorf("custom matcher made %d calls, want 2, components with custom matchers aren't cached", calls)
+	}
+}
+
+func TestComponent_MatchCacheConcurrent(t *testing.T) {
+	defer enableMatchCache(4096)()
+	component := suiteComponent()
+	if err := component.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	tests := make([]*v1.TestInfo, 50)
+	for i := range tests {
+		tests[i] = &v1.TestInfo{Name: fmt.Sprintf("test %d", i), Suite: "openshift-tests"}
+	}
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, test := range tests {
+				if component.FindMatch(test) == nil {
+					t.Errorf("FindMatch(%q) = nil, want a match", test.Name)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := component.matchCache.len(); got != len(tests) {
+		t.Errorf("cache holds %d matches, want %d", got, len(tests))
+	}
+}
+
+func TestComponent_MatchCacheSettingsChange(t *testing.T) {
+	defer enableMatchCache(4096)()
+	defer SetNormalizedTrailingTags("Suite")
+	defer ResetNamespacePatterns()
+
+	component := &Component{
+		Name:                 "Etcd",
+		DefaultJiraComponent: "Etcd",
+		NormalizeNames:       true,
+		Namespaces:           []string{"openshift-etcd"},
+		Matchers: []ComponentMatcher{
+			{NameSuffix: "etcd is healthy"},
+		},
+	}
+	if err := component.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	tagged := &v1.TestInfo{Name: "etcd is healthy [Foo:bar]"}
+	if m := component.FindMatch(tagged); m != nil {
+		t.Fatalf("FindMatch() = %+v, want nil before [Foo] tags are normalized", m)
+	}
+	SetNormalizedTrailingTags("Suite", "Foo")
+	if m := component.FindMatch(tagged); m == nil {
+		t.Errorf("FindMatch() = nil after SetNormalizedTrailingTags, want the cached miss discarded")
+	}
+
+	namespaced := &v1.TestInfo{Name: "pods are ready in pod openshift-etcd"}
+	if m := component.FindMatch(namespaced); m != nil {
+		t.Fatalf("FindMatch() = %+v, want nil before the namespace pattern is registered", m)
+	}
+	if err := RegisterNamespacePattern(`in pod (?P<Namespace>[-\w]+)`); err != nil {
+		t.Fatalf("RegisterNamespacePattern() error = %v", err)
+	}
+	if m := component.FindMatch(namespaced); m == nil {
+		t.Errorf("FindMatch() = nil after RegisterNamespacePattern, want the cached miss discarded")
+	}
+}
@@ -0,0 +1,685 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+	"github.com/openshift-eng/ci-test-mapping/pkg/util"
+)
+
+// compiledMatcher holds the precompiled state for a ComponentMatcher, so it's computed
+// once rather than on every call to FindMatch. It's read-only once compiled.
+type compiledMatcher struct {
+	// caseInsensitive means some of the substrings below have been lowercased, and must be
+	// compared to the lowercased test name.
+	caseInsensitive  bool
+	topLevelContains []substring
+	includeAll       [][]substring
+	includeAny       []substring
+	includeNone      []substring
+	excludeAll       []substring
+	excludeAny       []substring
+	suiteIncludeAll  []substring
+	suiteIncludeAny  []substring
+
+	includeWords   []*regexp.Regexp
+	excludeWords   []*regexp.Regexp
+	includeGlobs   []*regexp.Regexp
+	excludeGlobs   []*regexp.Regexp
+	pathGlobs      []*regexp.Regexp
+	includeRegexes []*regexp.Regexp
+	nameRegex      *regexp.Regexp
+	number         *compiledNumber
+	expr           exprNode
+
+	// dynamicCapabilities means some of the matcher's Capabilities refer to capture groups,
+	// and must be expanded for each test, see expandCapabilities.
+	dynamicCapabilities bool
+}
+
+// substring is a compiled IncludeAll, IncludeAny, IncludeNone, ExcludeAll or ExcludeAny entry.
+type substring struct {
+	// value is lowercased when caseInsensitive is set.
+	value           string
+	caseInsensitive bool
+}
+
+// testName is a test name in the forms compared to a matcher's substrings.
+type testName struct {
+	name  string
+	lower string
+}
+
+// testName returns the forms of the test name to compare the matcher's substrings to,
+// only lowercasing it if some substrings are case-insensitive.
+func (cm *compiledMatcher) testName(test *v1.TestInfo) testName {
+	name := testName{name: test.Name}
+	if cm.caseInsensitive {
+		name.lower = strings.ToLower(test.Name)
+	}
+	return name
+}
+
+// cleanName is like testName, for the test name without its bracketed tags, see
+// util.StripTags.
+func (cm *compiledMatcher) cleanName(test *v1.TestInfo) testName {
+	name := testName{name: util.StripTags(test.Name)}
+	if cm.caseInsensitive {
+		name.lower = strings.ToLower(name.name)
+	}
+	return name
+}
+
+// suiteName is like testName, for the test's suite. It's always lowercased too, as the
+// suite substrings aren't included in caseInsensitive.
+func (cm *compiledMatcher) suiteName(test *v1.TestInfo) testName {
+	return testName{name: test.Suite, lower: strings.ToLower(test.Suite)}
+}
+
+// topLevelName is like testName, for the first segment of the test name's hierarchy.
+func (cm *compiledMatcher) topLevelName(test *v1.TestInfo) testName {
+	name := testName{name: util.SplitTestHierarchy(test.Name)[0]}
+	if cm.caseInsensitive {
+		name.lower = strings.ToLower(name.name)
+	}
+	return name
+}
+
+func (s substring) in(name testName) bool {
+	if s.caseInsensitive {
+		return strings.Contains(name.lower, s.value)
+	}
+	return strings.Contains(name.name, s.value)
+}
+
+func containsAll(name testName, substrings []substring) bool {
+	for _, str := range substrings {
+		if !str.in(name) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAny(name testName, substrings []substring) bool {
+	for _, str := range substrings {
+		if str.in(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Compile precompiles the state used by the component's matchers, rename rules,
+// namespace patterns and operator test patterns, and returns an error if any of them are
+// invalid. It's called by the registry when the component is registered, and must be
+// called again if the component is modified afterwards. Components that haven't been
+// compiled still work, but their patterns are compiled on every call to FindMatch, and
+// their matchers' templates, see TemplateRef, aren't applied. Compile merges the templates
+// into a copy of the Matchers, leaving them as written, and if SortMatchers is set, sorts
+// the copy too, see EffectiveMatchers. Each compile starts a new match cache, see
+// MatchCacheSize, so recompiling discards matches made under the old configuration.
+func (c *Component) Compile() error {
+	matchers, err := c.resolveTemplates()
+	if err != nil {
+		return err
+	}
+	if c.SortMatchers {
+		matchers = sortMatchers(matchers)
+	}
+
+	compiled := make([]*compiledMatcher, len(matchers))
+	for i := range matchers {
+		cm, err := matchers[i].compile()
+		if err != nil {
+			return fmt.Errorf("component %q matcher %d: %w", c.Name, i, err)
+		}
+		compiled[i] = cm
+	}
+
+	renameRules := make([]*regexp.Regexp, len(c.TestRenameRules))
+	for i, rule := range c.TestRenameRules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("component %q rename rule %d: %w", c.Name, i, err)
+		}
+		renameRules[i] = re
+	}
+
+	namespacePatterns := make([]*regexp.Regexp, len(c.NamespacePatterns))
+	for i, pattern := range c.NamespacePatterns {
+		re, err := compileNamespacePattern(pattern)
+		if err != nil {
+			return fmt.Errorf("component %q namespace pattern %d: %w", c.Name, i, err)
+		}
+		namespacePatterns[i] = re
+	}
+
+	var jiraComponentRegex *regexp.Regexp
+	if c.JiraComponentRegex != "" {
+		if jiraComponentRegex, err = compileJiraComponentRegex(c.JiraComponentRegex); err != nil {
+			return fmt.Errorf("component %q jira component regex: %w", c.Name, err)
+		}
+	}
+
+	operatorTestPatterns := make(map[string][]*regexp.Regexp, len(c.OperatorTestPatterns))
+	for operator, patterns := range c.OperatorTestPatterns {
+		compiled, err := compileOperatorTestPatterns(patterns)
+		if err != nil {
+			return fmt.Errorf("component %q operator %q test pattern: %w", c.Name, operator, err)
+		}
+		operatorTestPatterns[operator] = compiled
+	}
+
+	c.resolvedMatchers = matchers
+	c.compiledMatchers = compiled
+	c.compiledRenameRules = renameRules
+	c.compiledNamespacePatterns = namespacePatterns
+	c.compiledJiraComponentRegex = jiraComponentRegex
+	c.compiledOperatorTestPatterns = operatorTestPatterns
+	stages := c.computeStages()
+	c.compiledStages = &stages
+	c.matchCache = nil
+	if len(c.CustomMatchers) == 0 {
+		c.matchCache = newMatchCache(MatchCacheSize)
+	}
+	return nil
+}
+
+// sortMatchers returns a copy of the matchers sorted by priority, highest first, then by
+// their JSON serialization, so the order only depends on what the matchers are.
+func sortMatchers(matchers []ComponentMatcher) []ComponentMatcher {
+	if matchers == nil {
+		return nil
+	}
+	type keyedMatcher struct {
+		key     string
+		matcher ComponentMatcher
+	}
+	keyed := make([]keyedMatcher, len(matchers))
+	for i, m := range matchers {
+		// ComponentMatcher only has strings, numbers, bools, and pointers, slices and maps
+		// of them, so it always serializes.
+		key, _ := json.Marshal(m)
+		keyed[i] = keyedMatcher{key: string(key), matcher: m}
+	}
+	sort.SliceStable(keyed, func(i, j int) bool {
+		if a, b := keyed[i].matcher.EffectivePriority(), keyed[j].matcher.EffectivePriority(); a != b {
+			return a > b
+		}
+		return keyed[i].key < keyed[j].key
+	})
+
+	sorted := make([]ComponentMatcher, len(keyed))
+	for i := range keyed {
+		sorted[i] = keyed[i].matcher
+	}
+	return sorted
+}
+
+// componentStages records which of FindMatch's stages apply to a component, so the
+// others can be skipped.
+type componentStages struct {
+	// operators is set when the component has operators, for the operator stage.
+	operators bool
+
+	// namespaces is set when the component owns namespaces, for the namespace stage.
+	namespaces bool
+
+	// jiraRegex is set when the component has a JiraComponentRegex, for the jira-regex stage.
+	jiraRegex bool
+}
+
+// stages returns which of FindMatch's stages apply to the component, computing them if
+// the component hasn't been compiled.
+func (c *Component) stages() componentStages {
+	if c.compiledStages != nil {
+		return *c.compiledStages
+	}
+	return c.computeStages()
+}
+
+func (c *Component) computeStages() componentStages {
+	return componentStages{
+		operators:  len(c.Operators) > 0 || len(c.OperatorConfigs) > 0 || len(c.OperatorTestPatterns) > 0,
+		namespaces: len(c.Namespaces) > 0,
+		jiraRegex:  c.JiraComponentRegex != "",
+	}
+}
+
+// jiraComponentRegex returns the compiled JiraComponentRegex, compiling it on demand if the
+// component hasn't been compiled. It returns nil if the pattern is unset or invalid.
+func (c *Component) jiraComponentRegex() *regexp.Regexp {
+	if c.compiledJiraComponentRegex != nil || c.JiraComponentRegex == "" {
+		return c.compiledJiraComponentRegex
+	}
+
+	re, err := compileJiraComponentRegex(c.JiraComponentRegex)
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// compileJiraComponentRegex compiles a JiraComponentRegex, which must have a named
+// Component capture group.
+func compileJiraComponentRegex(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if re.SubexpIndex("Component") < 0 {
+		return nil, fmt.Errorf("pattern %q has no Component capture group", pattern)
+	}
+	return re, nil
+}
+
+// operatorTestPatterns returns the compiled OperatorTestPatterns for the operator,
+// compiling them on demand if the component hasn't been compiled. It returns nil if any
+// of the patterns are invalid.
+func (c *Component) operatorTestPatterns(operator string) []*regexp.Regexp {
+	if len(c.compiledOperatorTestPatterns) == len(c.OperatorTestPatterns) {
+		return c.compiledOperatorTestPatterns[operator]
+	}
+
+	compiled, err := compileOperatorTestPatterns(c.OperatorTestPatterns[operator])
+	if err != nil {
+		return nil
+	}
+	return compiled
+}
+
+func compileOperatorTestPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// compiledNamespacePattern returns the compiled namespace pattern at index i, compiling
+// it on demand if the component hasn't been compiled. It returns nil if the pattern is
+// invalid.
+func (c *Component) compiledNamespacePattern(i int) *regexp.Regexp {
+	if len(c.compiledNamespacePatterns) == len(c.NamespacePatterns) {
+		return c.compiledNamespacePatterns[i]
+	}
+
+	re, err := compileNamespacePattern(c.NamespacePatterns[i])
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// compileNamespacePattern compiles a namespace pattern, which must have a named Namespace
+// capture group.
+func compileNamespacePattern(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if re.SubexpIndex("Namespace") < 0 {
+		return nil, fmt.Errorf("pattern %q has no Namespace capture group", pattern)
+	}
+	return re, nil
+}
+
+// compiledRenameRule returns the compiled pattern for the rename rule at index i,
+// compiling it on demand if the component hasn't been compiled. It returns nil if the
+// pattern is invalid.
+func (c *Component) compiledRenameRule(i int) *regexp.Regexp {
+	if len(c.compiledRenameRules) == len(c.TestRenameRules) {
+		return c.compiledRenameRules[i]
+	}
+
+	re, err := regexp.Compile(c.TestRenameRules[i].Pattern)
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// EffectiveMatchers returns the matchers FindMatch evaluates, which matcher indexes, e.g.
+// in MatchReason or ExplainNonMatch, refer to. Once the component is compiled, they're the
+// Matchers with their templates merged in, see TemplateRef, sorted if SortMatchers is set;
+// until then, they're the Matchers as written.
+func (c *Component) EffectiveMatchers() []ComponentMatcher {
+	if len(c.compiledMatchers) == len(c.Matchers) && c.resolvedMatchers != nil {
+		return c.resolvedMatchers
+	}
+	return c.Matchers
+}
+
+// compiledMatcher returns the precompiled state for the matcher at index i of
+// EffectiveMatchers, compiling it on demand if the component hasn't been compiled. It
+// returns nil if the matcher's patterns are invalid.
+func (c *Component) compiledMatcher(i int) *compiledMatcher {
+	if len(c.compiledMatchers) == len(c.Matchers) {
+		return c.compiledMatchers[i]
+	}
+
+	cm, err := c.Matchers[i].compile()
+	if err != nil {
+		return nil
+	}
+	return cm
+}
+
+func (cm *ComponentMatcher) compile() (*compiledMatcher, error) {
+	var err error
+	compiled := &compiledMatcher{
+		topLevelContains: compileSubstrings(cm.TopLevelContains, cm.CaseInsensitive),
+		includeAll:       compileAlternations(cm.IncludeAll, cm.CaseInsensitive),
+		includeAny:       compileSubstrings(cm.IncludeAny, cm.CaseInsensitive),
+		includeNone:      compileSubstrings(cm.IncludeNone, cm.CaseInsensitive),
+		excludeAll:       compileSubstrings(cm.ExcludeAll, cm.CaseInsensitive),
+		excludeAny:       compileSubstrings(cm.ExcludeAny, cm.CaseInsensitive),
+		suiteIncludeAll:  compileSubstrings(cm.SuiteIncludeAll, cm.CaseInsensitive),
+		suiteIncludeAny:  compileSubstrings(cm.SuiteIncludeAny, cm.CaseInsensitive),
+	}
+	for _, substrings := range append([][]substring{compiled.topLevelContains, compiled.includeAny, compiled.includeNone, compiled.excludeAll, compiled.excludeAny}, compiled.includeAll...) {
+		for _, str := range substrings {
+			compiled.caseInsensitive = compiled.caseInsensitive || str.caseInsensitive
+		}
+	}
+
+	compiled.includeWords = compileWords(cm.IncludeWord, cm.CaseInsensitive)
+	compiled.excludeWords = compileWords(cm.ExcludeWord, cm.CaseInsensitive)
+
+	if compiled.includeGlobs, err = compileGlobs(cm.IncludeGlob); err != nil {
+		return nil, err
+	}
+	if compiled.excludeGlobs, err = compileGlobs(cm.ExcludeGlob); err != nil {
+		return nil, err
+	}
+	for _, pattern := range cm.PathGlob {
+		re, err := util.CompilePathGlob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("pathGlob: %w", err)
+		}
+		compiled.pathGlobs = append(compiled.pathGlobs, re)
+	}
+	for _, pattern := range cm.IncludeRegex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("includeRegex: %w", err)
+		}
+		compiled.includeRegexes = append(compiled.includeRegexes, re)
+	}
+
+	if cm.NameMatchesRegex != "" {
+		if compiled.nameRegex, err = regexp.Compile(`^(?:` + cm.NameMatchesRegex + `)$`); err != nil {
+			return nil, fmt.Errorf("nameMatchesRegex: %w", err)
+		}
+	}
+
+	if cm.Number != nil {
+		if compiled.number, err = compileNumber(cm.Number); err != nil {
+			return nil, err
+		}
+	}
+
+	if cm.Expr != "" {
+		if cm.Specificity() > 1 {
+			return nil, fmt.Errorf("expr can't be combined with other conditions")
+		}
+		if compiled.expr, err = parseExpr(cm.Expr, cm.CaseInsensitive); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, capability := range cm.Capabilities {
+		if strings.Contains(capability, "$") {
+			if len(compiled.includeRegexes) == 0 {
+				return nil, fmt.Errorf("capability %q refers to a capture group, but there's no includeRegex", capability)
+			}
+			if cm.Negate {
+				return nil, fmt.Errorf("capability %q refers to a capture group, but the matcher is negated, so its includeRegex doesn't match the tests it claims", capability)
+			}
+			re := compiled.includeRegexes[0]
+			for _, group := range captureGroupReferences(capability) {
+				if !hasCaptureGroup(re, group) {
+					return nil, fmt.Errorf("capability %q refers to capture group %q, which includeRegex %q doesn't have", capability, group, cm.IncludeRegex[0])
+				}
+			}
+			compiled.dynamicCapabilities = true
+		}
+	}
+
+	return compiled, nil
+}
+
+// expandCapabilities returns the capabilities with their capture group references, e.g.
+// $1 or ${phase}, replaced by the text captured from the test name by the first
+// IncludeRegex. Capabilities that expand to nothing are dropped.
+func (cm *compiledMatcher) expandCapabilities(capabilities []string, testName string) []string {
+	re := cm.includeRegexes[0]
+	submatches := re.FindStringSubmatchIndex(testName)
+	if submatches == nil {
+		return capabilities
+	}
+
+	expanded := make([]string, 0, len(capabilities))
+	for _, capability := range capabilities {
+		if strings.Contains(capability, "$") {
+			capability = string(re.ExpandString(nil, capability, testName, submatches))
+		}
+		if capability != "" {
+			expanded = append(expanded, capability)
+		}
+	}
+	return expanded
+}
+
+// captureGroupReferences returns the names of the capture groups a capability refers to,
+// e.g. "1" and "phase" for "$1-${phase}", following regexp.Expand's syntax, where $$ is a
+// literal $ and a $ not followed by a valid name is kept as it is.
+func captureGroupReferences(capability string) []string {
+	var groups []string
+	for i := 0; i < len(capability); i++ {
+		if capability[i] != '$' {
+			continue
+		}
+		i++
+		if i < len(capability) && capability[i] == '$' {
+			continue
+		}
+		brace := i < len(capability) && capability[i] == '{'
+		if brace {
+			i++
+		}
+		end := i
+		for end < len(capability) && isCaptureGroupNameByte(capability[end]) {
+			end++
+		}
+		if end == i || (brace && (end == len(capability) || capability[end] != '}')) {
+			i--
+			continue
+		}
+		groups = append(groups, capability[i:end])
+		i = end
+		if !brace {
+			i--
+		}
+	}
+	return groups
+}
+
+func isCaptureGroupNameByte(b byte) bool {
+	return b == '_' || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+// hasCaptureGroup reports whether the regular expression has the capture group, by index,
+// e.g. "1", or by name.
+func hasCaptureGroup(re *regexp.Regexp, group string) bool {
+	if index, err := strconv.Atoi(group); err == nil {
+		return index <= re.NumSubexp()
+	}
+	return re.SubexpIndex(group) >= 0
+}
+
+func compileSubstrings(substrings []string, caseInsensitive bool) []substring {
+	compiled := make([]substring, len(substrings))
+	for i, str := range substrings {
+		value, fold := parseSubstring(str, caseInsensitive)
+		if fold {
+			value = strings.ToLower(value)
+		}
+		compiled[i] = substring{value: value, caseInsensitive: fold}
+	}
+	return compiled
+}
+
+// compileAlternations compiles each IncludeAll entry into its alternatives, see
+// splitAlternation.
+func compileAlternations(entries []string, caseInsensitive bool) [][]substring {
+	compiled := make([][]substring, len(entries))
+	for i, entry := range entries {
+		compiled[i] = compileSubstrings(splitAlternation(entry), caseInsensitive)
+	}
+	return compiled
+}
+
+// splitAlternation returns the pipe-separated alternatives of an IncludeAll entry, e.g.
+// "CSI" and "i/in-tree" for "CSI|i/in-tree", or just the entry if it has no pipes.
+func splitAlternation(entry string) []string {
+	return strings.Split(entry, "|")
+}
+
+// compiledNumber is a compiled NumberCondition.
+type compiledNumber struct {
+	re    *regexp.Regexp
+	group int
+
+	operator  string
+	threshold float64
+}
+
+// numberOperators are the comparisons a NumberCondition Threshold may use.
+var numberOperators = map[string]func(a, b float64) bool{
+	"<":  func(a, b float64) bool { return a < b },
+	"<=": func(a, b float64) bool { return a <= b },
+	"==": func(a, b float64) bool { return a == b },
+	"!=": func(a, b float64) bool { return a != b },
+	">=": func(a, b float64) bool { return a >= b },
+	">":  func(a, b float64) bool { return a > b },
+}
+
+func compileNumber(condition *NumberCondition) (*compiledNumber, error) {
+	re, err := regexp.Compile(condition.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("number pattern: %w", err)
+	}
+	group := re.SubexpIndex("Number")
+	if group < 0 {
+		return nil, fmt.Errorf("number pattern %q has no Number capture group", condition.Pattern)
+	}
+
+	operator, threshold, ok := parseThreshold(condition.Threshold)
+	if !ok {
+		return nil, fmt.Errorf(`number threshold %q is not an operator followed by a number, e.g. ">= 50"`, condition.Threshold)
+	}
+
+	return &compiledNumber{re: re, group: group, operator: operator, threshold: threshold}, nil
+}
+
+// parseThreshold splits a NumberCondition Threshold into its operator and number. The
+// space between them is optional, e.g. ">=50".
+func parseThreshold(threshold string) (string, float64, bool) {
+	threshold = strings.TrimSpace(threshold)
+	number := strings.TrimLeft(threshold, "<=>!")
+	operator := threshold[:len(threshold)-len(number)]
+	if numberOperators[operator] == nil {
+		return "", 0, false
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return operator, value, true
+}
+
+// matches reports whether the test name has a number meeting the threshold.
+func (n *compiledNumber) matches(testName string) bool {
+	submatches := n.re.FindStringSubmatchIndex(testName)
+	if submatches == nil || submatches[2*n.group] < 0 {
+		return false
+	}
+	number, err := strconv.ParseFloat(testName[submatches[2*n.group]:submatches[2*n.group+1]], 64)
+	if err != nil {
+		return false
+	}
+	return numberOperators[n.operator](number, n.threshold)
+}
+
+// compileWords compiles IncludeWord or ExcludeWord entries to regular expressions matching
+// them as whole words. The \b anchors are only added next to word characters, so words
+// beginning or ending with punctuation, e.g. "[Serial]", are still matched literally.
+func compileWords(words []string, caseInsensitive bool) []*regexp.Regexp {
+	var compiled []*regexp.Regexp
+	for _, word := range words {
+		word, fold := parseSubstring(word, caseInsensitive)
+		pattern := regexp.QuoteMeta(word)
+		if isWordStart(word) {
+			pattern = `\b` + pattern
+		}
+		if isWordEnd(word) {
+			pattern += `\b`
+		}
+		if fold {
+			pattern = "(?i)" + pattern
+		}
+		compiled = append(compiled, regexp.MustCompile(pattern))
+	}
+	return compiled
+}
+
+// isWordStart and isWordEnd report whether the word begins or ends with a word character,
+// as matched by \w.
+func isWordStart(word string) bool {
+	return word != "" && isWordChar(word[0])
+}
+
+func isWordEnd(word string) bool {
+	return word != "" && isWordChar(word[len(word)-1])
+}
+
+func isWordChar(b byte) bool {
+	return b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// matchesAnyGlob reports whether any of the globs match the path. An empty path matches
+// none of them.
+func matchesAnyGlob(globs []*regexp.Regexp, path string) bool {
+	if path == "" {
+		return false
+	}
+	for _, glob := range globs {
+		if glob.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func compileGlobs(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := util.CompileGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
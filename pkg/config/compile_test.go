@@ -0,0 +1,226 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func benchmarkComponent() *Component {
+	c := &Component{
+		Name:                 "Networking / router",
+		DefaultJiraComponent: "Networking / router",
+		Namespaces:           []string{"openshift-ingress", "openshift-ingress-operator"},
+		NamespacePatterns:    []string{`in namespace "(?P<Namespace>[-\w]+)"`},
+		TestRenameRules:      []RenameRule{{Pattern: `\[MinimumKubeletVersion:[^\]]+\]`}},
+	}
+	for i := 0; i < 20; i++ {
+		c.Matchers = append(c.Matchers,
+			ComponentMatcher{IncludeGlob: []string{fmt.Sprintf("*router-%d *", i)}, ExcludeGlob: []string{"*[[]Disruptive]*"}},
+			ComponentMatcher{IncludeAny: []string{fmt.Sprintf("Ingress-%d", i), fmt.Sprintf("Route-%d", i)}, CaseInsensitive: true},
+		)
+	}
+	return c
+}
+
+func benchmarkTests() []*v1.TestInfo {
+	var tests []*v1.TestInfo
+	for i := 0; i < 100; i++ {
+		tests = append(tests, &v1.TestInfo{
+			Name:  fmt.Sprintf("[sig-network-edge][Feature:Router] The HAProxy router-%d should serve routes [Suite:openshift/conformance/parallel]", i),
+			Suite: "openshift-tests",
+		})
+	}
+	return tests
+}
+
+func BenchmarkComponent_FindMatch(b *testing.B) {
+	tests := benchmarkTests()
+
+	b.Run("uncompiled", func(b *testing.B) {
+		c := benchmarkComponent()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c.FindMatch(tests[i%len(tests)])
+		}
+	})
+
+	b.Run("compiled", func(b *testing.B) {
+		c := benchmarkComponent()
+		if err := c.Compile(); err != nil {
+			b.Fatalf("Compile() returned unexpected error: %+v", err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c.FindMatch(tests[i%len(tests)])
+		}
+	})
+}
+
+func BenchmarkComponent_FindMatchSubstringOnly(b *testing.B) {
+	// Most components don't own most tests, so the benchmark uses tests none of the
+	// matchers match, e.g. the tests of other components.
+	var tests []*v1.TestInfo
+	for i := 0; i < 100; i++ {
+		tests = append(tests, &v1.TestInfo{
+			Name:  fmt.Sprintf("[sig-storage] CSI volume-%d should mount in ns/e2e-csi-%d [Suite:openshift/conformance/parallel]", i, i),
+			Suite: "openshift-tests",
+		})
+	}
+	substringOnly := func() *Component {
+		return &Component{
+			Name:                 "Networking / DNS",
+			DefaultJiraComponent: "Networking / DNS",
+			Matchers: []ComponentMatcher{
+				{IncludeAny: []string{"dns-operator", "CoreDNS"}},
+				{IncludeAll: []string{"[sig-network]", "DNS"}},
+			},
+		}
+	}
+
+	b.Run("substring-only", func(b *testing.B) {
+		c := substringOnly()
+		if err := c.Compile(); err != nil {
+			b.Fatalf("Compile() returned unexpected error: %+v", err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c.FindMatch(tests[i%len(tests)])
+		}
+	})
+
+	// The same matchers, but with an operator and a namespace, so FindMatch can't skip the
+	// operator and namespace stages.
+	b.Run("all-stages", func(b *testing.B) {
+		c := substringOnly()
+		c.Operators = []string{"dns"}
+		c.Namespaces = []string{"openshift-dns", "openshift-dns-operator"}
+		if err := c.Compile(); err != nil {
+			b.Fatalf("Compile() returned unexpected error: %+v", err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c.FindMatch(tests[i%len(tests)])
+		}
+	})
+}
+
+func TestCompileWords(t *testing.T) {
+	tests := []struct {
+		word    string
+		name    string
+		matches bool
+	}{
+		{word: "dns", name: "the dns operator", matches: true},
+		{word: "dns", name: "dns", matches: true},
+		{word: "dns", name: "[sig-network] dns-default should resolve", matches: true},
+		{word: "dns", name: "windns should resolve", matches: false},
+		{word: "dns", name: "dnsmasq should resolve", matches: false},
+		{word: "dns", name: "the DNS operator", matches: false},
+		{word: "i/dns", name: "the DNS operator", matches: true},
+		{word: "dns.operator", name: "the dns-operator", matches: false},
+		{word: "[Serial]", name: "should recover [Serial]", matches: true},
+		{word: "sig-network", name: "[sig-network-edge] should route", matches: true},
+		{word: "sig-network", name: "[sig-networking] should route", matches: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word+" in "+tt.name, func(t *testing.T) {
+			compiled := compileWords([]string{tt.word}, false)
+			if got := compiled[0].MatchString(tt.name); got != tt.matches {
+				t.Errorf("compileWords(%q) matches %q = %v, want %v", tt.word, tt.name, got, tt.matches)
+			}
+		})
+	}
+}
+
+func TestCompileNumber(t *testing.T) {
+	tests := []struct {
+		condition NumberCondition
+		wantErr   string
+	}{
+		{condition: NumberCondition{Pattern: `(?P<Number>\d+) pods`, Threshold: "< 1.5"}},
+		{condition: NumberCondition{Pattern: `(?P<Number>-?\d+) pods`, Threshold: "==-3"}},
+		{
+			condition: NumberCondition{Pattern: `(\d+) pods`, Threshold: ">= 50"},
+			wantErr:   `number pattern "(\\d+) pods" has no Number capture group`,
+		},
+		{
+			condition: NumberCondition{Pattern: `(?P<Number>\d+ pods`, Threshold: ">= 50"},
+			wantErr:   "number pattern: error parsing regexp: missing closing ): `(?P<Number>\\d+ pods`",
+		},
+		{
+			condition: NumberCondition{Pattern: `(?P<Number>\d+) pods`, Threshold: "=> 50"},
+			wantErr:   `number threshold "=> 50" is not an operator followed by a number, e.g. ">= 50"`,
+		},
+		{
+			condition: NumberCondition{Pattern: `(?P<Number>\d+) pods`, Threshold: ">= fifty"},
+			wantErr:   `number threshold ">= fifty" is not an operator followed by a number, e.g. ">= 50"`,
+		},
+		{
+			condition: NumberCondition{Pattern: `(?P<Number>\d+) pods`, Threshold: "50"},
+			wantErr:   `number threshold "50" is not an operator followed by a number, e.g. ">= 50"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition.Threshold, func(t *testing.T) {
+			_, err := compileNumber(&tt.condition)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("compileNumber() returned error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("compileNumber() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestComponent_CompileSortMatchers(t *testing.T) {
+	sdn := ComponentMatcher{IncludeAll: []string{"egress"}, JiraComponent: "Networking / openshift-sdn"}
+	ovn := ComponentMatcher{IncludeAll: []string{"egress"}, JiraComponent: "Networking / ovn-kubernetes"}
+	router := ComponentMatcher{IncludeAll: []string{"egress"}, JiraComponent: "Networking / router", Priority: 1}
+	test := &v1.TestInfo{Name: "[sig-network] egress should work"}
+
+	var want []ComponentMatcher
+	for _, matchers := range [][]ComponentMatcher{
+		{sdn, ovn, router},
+		{ovn, router, sdn},
+		{router, sdn, ovn},
+	} {
+		c := &Component{
+			DefaultJiraComponent: "Networking",
+			SortMatchers:         true,
+			Matchers:             matchers,
+		}
+		if err := c.Compile(); err != nil {
+			t.Fatal(err)
+		}
+		if want == nil {
+			want = c.EffectiveMatchers()
+		}
+		if !reflect.DeepEqual(c.EffectiveMatchers(), want) {
+			t.Errorf("Compile() sorted %v to %v, want %v", matchers, c.EffectiveMatchers(), want)
+		}
+		if !reflect.DeepEqual(c.Matchers, matchers) {
+			t.Errorf("Compile() reordered Matchers to %v, want them left as written", c.Matchers)
+		}
+		if got := c.FindMatch(test); got == nil || got.JiraComponent != "Networking / router" {
+			t.Errorf("FindMatch() = %+v, want the highest priority matcher", got)
+		}
+	}
+	if want[1].JiraComponent != sdn.JiraComponent {
+		t.Errorf("Compile() sorted equal priority matchers to %v, want them ordered by their fields", want)
+	}
+
+	unsorted := &Component{DefaultJiraComponent: "Networking", Matchers: []ComponentMatcher{ovn, sdn}}
+	if err := unsorted.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if got := unsorted.FindMatch(test); got == nil || got.JiraComponent != ovn.JiraComponent {
+		t.Errorf("FindMatch() = %+v, want the first matcher when SortMatchers isn't set", got)
+	}
+}
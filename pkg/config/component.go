@@ -1,9 +1,14 @@
 package config
 
 import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
 	"regexp"
-	"strconv"
+	"sort"
 	"strings"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -11,124 +16,1278 @@ import (
 	"github.com/openshift-eng/ci-test-mapping/pkg/util"
 )
 
+// The priority constants are named bands for matchers' priorities, from lowest to highest,
+// so components choose from a controlled range rather than stacking arbitrary numbers. A
+// matcher may use a value between two bands to order itself within them, but two matchers
+// sharing a priority and matching the same tests are reported by LintPriorityCollisions.
+const (
+	// PriorityCatchAll is the priority for catch-all matchers, that should only own a test
+	// when nothing else claims it.
+	PriorityCatchAll = -100
+
+	// PriorityFallback is for broad matchers that defer to the default matchers of any
+	// component, e.g. a SIG-wide matcher.
+	PriorityFallback = -10
+
+	// PriorityDefault is the priority of matches that don't set one, including
+	// Jira field and operator matches.
+	PriorityDefault = 0
+
+	// PrioritySpecific is for matchers that carve tests out of another component's
+	// default matchers, without overriding namespace ownership.
+	PrioritySpecific = 5
+
+	// PriorityNamespace is the default priority of namespace ownership matches, see
+	// Component.NamespacePriority. Matchers must use a higher priority to override a test's
+	// namespace ownership.
+	PriorityNamespace = 10
+
+	// PriorityOverride is for matchers that override a test's namespace ownership, e.g.
+	// disruption tests moved to the component most likely at fault.
+	PriorityOverride = 20
+
+	// PriorityForce is the last resort, for matchers that must win over every other
+	// band.
+	PriorityForce = 100
+)
+
 // Component is the default configuration struct that you can include in your
 // own component implementation. It includes a matcher help that will identify
 // if a test belongs to a sig, operator, as well as simple substring matching.
 // Components do not need to use this framework, it's an optional add-on.
 type Component struct {
-	Name                 string
-	DefaultJiraProject   string
-	DefaultJiraComponent string
-	Matchers             []ComponentMatcher
-	Operators            []string
-	Namespaces           []string
+	Name string `json:"name" yaml:"name"`
+	// Parent is the name of an umbrella component whose fallback rules defer to this
+	// component's claims, see LinkParents and Resolve.
+	Parent               string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	DefaultJiraProject   string `json:"defaultJiraProject,omitempty" yaml:"defaultJiraProject,omitempty"`
+	DefaultJiraComponent string `json:"defaultJiraComponent,omitempty" yaml:"defaultJiraComponent,omitempty"`
+	// Aliases are former names of DefaultJiraComponent, e.g. after the Jira component was
+	// renamed, still referenced by historical data. They're equivalent to it when looking
+	// up a component by Jira component, see HasJiraComponent, and for the Jira field of
+	// tests, but tests are always assigned DefaultJiraComponent.
+	Aliases []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	// ExcludeJiraComponents are Jira field values that stop a test from being claimed by
+	// its Jira field naming DefaultJiraComponent, e.g. for tests re-homed to another
+	// component. Such tests may still be claimed by the component's other stages.
+	ExcludeJiraComponents []string `json:"excludeJiraComponents,omitempty" yaml:"excludeJiraComponents,omitempty"`
+	// DefaultCapabilities are added to the capabilities of every test the component
+	// claims, however it's claimed, so they needn't be repeated in each matcher.
+	DefaultCapabilities []string           `json:"defaultCapabilities,omitempty" yaml:"defaultCapabilities,omitempty"`
+	Matchers            []ComponentMatcher `json:"matchers,omitempty" yaml:"matchers,omitempty"`
+	Operators           []string           `json:"operators,omitempty" yaml:"operators,omitempty"`
+	// OperatorConfigs are operators owned by the component, like Operators, along with
+	// capabilities added to every one of the operator's tests. An operator may be in
+	// both lists.
+	OperatorConfigs []OperatorConfig `json:"operatorConfigs,omitempty" yaml:"operatorConfigs,omitempty"`
+	// OperatorTestPatterns are additional regular expressions, keyed by operator name,
+	// recognizing a test as one of the operator's tests when the built-in heuristics
+	// (see util.IdentifyOperatorTest) don't. If a pattern has a named Capability capture
+	// group, the captured text is the capability derived from the test, e.g.
+	// `(?P<Capability>install|upgrade) of the ingress operator`.
+	OperatorTestPatterns map[string][]string `json:"operatorTestPatterns,omitempty" yaml:"operatorTestPatterns,omitempty"`
+	Namespaces           []string            `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	// NamespacePriority is the priority of namespace ownership matches, PriorityNamespace
+	// when unset. Raise it for only very specific overrides to beat the component's
+	// ownership of its namespaces.
+	NamespacePriority int `json:"namespacePriority,omitempty" yaml:"namespacePriority,omitempty"`
+	// OwnsSIG lists SIGs the component owns outright: a test in one of them that none of
+	// the Matchers match is claimed at OwnsSIGPriority, before namespace ownership is
+	// considered. It avoids writing a broad catch-all matcher for the SIG.
+	OwnsSIG []string `json:"ownsSIG,omitempty" yaml:"ownsSIG,omitempty"`
+	// OwnsSIGPriority is the priority of OwnsSIG matches, PriorityDefault when unset.
+	OwnsSIGPriority int `json:"ownsSIGPriority,omitempty" yaml:"ownsSIGPriority,omitempty"`
+	// NamespacePatterns are additional regular expressions used to find the namespace
+	// a test references, for tests that don't use the ns/<name> or namespace/<name>
+	// forms, e.g. `in namespace "(?P<Namespace>[-\w]+)"`. Each must have a named
+	// Namespace capture group.
+	NamespacePatterns []string `json:"namespacePatterns,omitempty" yaml:"namespacePatterns,omitempty"`
+	// JiraComponentRegex is a regular expression finding the Jira component a test names
+	// other than in a [Jira:...] field, e.g. `\(owner: (?P<Component>[^)]+)\)`, with a named
+	// Component capture group. When nothing else in FindMatch claims the test, it's claimed
+	// if the captured value is the DefaultJiraComponent or one of the Aliases.
+	JiraComponentRegex string `json:"jiraComponentRegex,omitempty" yaml:"jiraComponentRegex,omitempty"`
 	// Variants defines the list of variants a component is responsible for. The format of
 	// each item is variantCategory:variantValue
-	Variants []string
+	Variants []string `json:"variants,omitempty" yaml:"variants,omitempty"`
+
+	// NormalizeNames compares the Matchers, CustomMatchers and OwnsSIG to test names
+	// normalized by NormalizeTestName, so names differing only in whitespace or trailing
+	// tags like [Suite:...] are matched the same. It doesn't affect the Jira field,
+	// operator or namespace stages of FindMatch.
+	NormalizeNames bool `json:"normalizeNames,omitempty" yaml:"normalizeNames,omitempty"`
+	// SortMatchers makes Compile sort the Matchers by priority, highest first, then by their
+	// serialized fields, so which of several matching matchers claims a test doesn't depend
+	// on the order they were listed, merged or loaded in. Matchers itself is left as
+	// written; matcher indexes, e.g. in MatchReason, refer to the sorted order, see
+	// EffectiveMatchers.
+	SortMatchers bool `json:"sortMatchers,omitempty" yaml:"sortMatchers,omitempty"`
 
 	// When a test is renamed, you can still look at results across releases by mapping new names
 	// to the oldest version of the test.
-	TestRenames map[string]string
+	TestRenames map[string]string `json:"testRenames,omitempty" yaml:"testRenames,omitempty"`
+
+	// TestRenameRules are regular expression renames, for tests whose names change in a
+	// predictable way (e.g. an embedded version number). They're applied in order to names
+	// that don't have an entry in TestRenames.
+	TestRenameRules []RenameRule `json:"testRenameRules,omitempty" yaml:"testRenameRules,omitempty"`
+
+	// CustomMatchers are procedural rules for tests that can't be matched declaratively.
+	// They're evaluated in order after Matchers, and the first to return a matcher claims
+	// the test with it, competing with other components by the returned matcher's
+	// Priority. They're not serialized.
+	CustomMatchers []func(*v1.TestInfo) *ComponentMatcher `json:"-" yaml:"-"`
+
+	// resolvedMatchers holds the Matchers with their templates merged in, sorted if
+	// SortMatchers is set, see EffectiveMatchers.
+	resolvedMatchers []ComponentMatcher
+
+	// compiledMatchers holds the precompiled state of resolvedMatchers, in the same order,
+	// see Compile.
+	compiledMatchers []*compiledMatcher
+
+	// compiledRenameRules holds the compiled TestRenameRules patterns, in the same order.
+	compiledRenameRules []*regexp.Regexp
+
+	// compiledNamespacePatterns holds the compiled NamespacePatterns, in the same order.
+	compiledNamespacePatterns []*regexp.Regexp
+
+	// compiledJiraComponentRegex holds the compiled JiraComponentRegex, nil if it's unset or
+	// the component hasn't been compiled.
+	compiledJiraComponentRegex *regexp.Regexp
+
+	// compiledOperatorTestPatterns holds the compiled OperatorTestPatterns.
+	compiledOperatorTestPatterns map[string][]*regexp.Regexp
+
+	// matchCache holds the matches of recently evaluated tests, nil if the component hasn't
+	// been compiled, has CustomMatchers, or MatchCacheSize was 0 when it was.
+	matchCache *matchCache
+
+	// compiledStages records which stages of FindMatch can match, nil if the component
+	// hasn't been compiled.
+	compiledStages *componentStages
+
+	// parent is the component named by Parent, once linked by LinkParents.
+	parent *Component
+}
+
+// OperatorConfig configures an operator owned by a component.
+type OperatorConfig struct {
+	// Name is the name of the operator, as in Component.Operators.
+	Name string `json:"name" yaml:"name"`
+
+	// Capabilities are merged into the capabilities derived from the operator's tests.
+	Capabilities []string `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+}
+
+// operatorConfig has OperatorConfig's fields, without its unmarshalling methods.
+type operatorConfig OperatorConfig
+
+// UnmarshalJSON accepts either a full OperatorConfig or, like Operators, just the
+// operator's name.
+func (oc *OperatorConfig) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*oc = OperatorConfig{Name: name}
+		return nil
+	}
+	return json.Unmarshal(data, (*operatorConfig)(oc))
+}
+
+// UnmarshalYAML accepts either a full OperatorConfig or, like Operators, just the
+// operator's name.
+func (oc *OperatorConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var name string
+	if err := unmarshal(&name); err == nil {
+		*oc = OperatorConfig{Name: name}
+		return nil
+	}
+	return unmarshal((*operatorConfig)(oc))
+}
+
+// RenameRule renames tests whose name matches a regular expression.
+type RenameRule struct {
+	// Pattern is the regular expression matched against the test name.
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+
+	// Replacement replaces every match of Pattern in the test name. It may reference capture
+	// groups from Pattern using $1 or ${name} syntax, as in regexp.Regexp.Expand.
+	Replacement string `json:"replacement,omitempty" yaml:"replacement,omitempty"`
+}
+
+// CanonicalName resolves a test name to the oldest name of the test, see ResolveRename.
+// If the renames contain a cycle, it returns the last name reached before the cycle.
+func (c *Component) CanonicalName(name string) string {
+	canonical, _ := c.ResolveRename(name)
+	return canonical
+}
+
+// TestID returns an identifier for the test that's stable across renames, for keying
+// stored results. It's the lowercase hex encoding of the MD5 hash of the test's suite, a
+// NUL byte, and its canonical name (see CanonicalName), so it only depends on those and
+// is reproducible across runs and releases; tests without a suite hash an empty one.
+func (c *Component) TestID(test *v1.TestInfo) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(test.Suite+"\x00"+c.CanonicalName(test.Name))))
+}
+
+// ResolveRename resolves a test name to the oldest name of the test. Exact renames in
+// TestRenames are followed transitively, so if A was renamed to B and B to C, A resolves
+// to C. When a name has no entry in TestRenames, the TestRenameRules are applied in order
+// instead, and the resulting name is again followed through TestRenames. If the renames
+// contain a cycle, it returns the last name reached before the cycle and an error.
+func (c *Component) ResolveRename(name string) (string, error) {
+	resolved, renamed, err := c.followRenames(name)
+	if err != nil || renamed {
+		return resolved, err
+	}
+
+	for i := range c.TestRenameRules {
+		re := c.compiledRenameRule(i)
+		if re == nil {
+			continue
+		}
+		resolved = re.ReplaceAllString(resolved, c.TestRenameRules[i].Replacement)
+	}
+	if resolved == name {
+		return name, nil
+	}
+
+	resolved, _, err = c.followRenames(resolved)
+	return resolved, err
+}
+
+// followRenames follows the exact renames in TestRenames starting from name, and reports
+// whether any rename was found.
+func (c *Component) followRenames(name string) (string, bool, error) {
+	seen := sets.New[string](name)
+	current := name
+	for {
+		next, ok := c.TestRenames[current]
+		if !ok || next == current {
+			return current, current != name, nil
+		}
+		if seen.Has(next) {
+			return current, true, fmt.Errorf("test renames for %q contain a cycle at %q", name, next)
+		}
+		seen.Insert(next)
+		current = next
+	}
 }
 
 // ComponentMatcher is used to match against a TestInfo struct. Note the fields SIG,
-// Suite, IncludeAll, and ExcludeAll are ANDed together. That is, all that have values must
-// match.  For include  and exclude, the individual items in the array are ANDed. That
-// is, if you  specify multiple substrings, all must match. Use separate component
-// matchers for an OR operation.
+// SIGAny, Suite, SuiteAny, SuitePrefix, SuiteIncludeAll, SuiteIncludeAny, ExcludeSuite,
+// Variants, Repo, RepoAny, PathGlob, Tags, Params, RequireFlags, Annotations,
+// IsDisruption, Serial, NoSIG, NamePrefix, NameSuffix, TopLevelContains, IncludeAll,
+// IncludeNone, IncludeWord, IncludeGlob, IncludeRegex, NameMatchesRegex, Number,
+// ExcludeAll, ExcludeWord and ExcludeGlob are ANDed together. That is, all that have
+// values must match.  For include  and exclude, the individual items in the array are
+// ANDed. That is, if you  specify multiple substrings, all must match. Use separate
+// component matchers for an OR operation, or Expr for arbitrary boolean logic, which
+// replaces them. Negate inverts the result of all of them together.
 //
 // The second set  of fields are metadata used to assign ownership.
 type ComponentMatcher struct {
-	SIG        string
-	Suite      string
-	IncludeAll []string
-	IncludeAny []string
-	ExcludeAll []string
-	ExcludeAny []string
+	SIG string `json:"sig,omitempty" yaml:"sig,omitempty"`
+	// SIGAny matches tests belonging to any of the listed SIGs. When SIG is also set,
+	// the test must belong to SIG as well.
+	SIGAny []string `json:"sigAny,omitempty" yaml:"sigAny,omitempty"`
+	// ExcludeSIG forces a non-match when the test belongs to any of the listed SIGs.
+	ExcludeSIG []string `json:"excludeSIG,omitempty" yaml:"excludeSIG,omitempty"`
+	Suite      string   `json:"suite,omitempty" yaml:"suite,omitempty"`
+	// SuiteAny matches tests in any of the listed suites. When Suite is also set, the
+	// test must be in Suite as well.
+	SuiteAny []string `json:"suiteAny,omitempty" yaml:"suiteAny,omitempty"`
+	// SuitePrefix matches tests whose suite begins with the prefix, e.g.
+	// "openshift/conformance/" for every conformance suite, without listing each of them.
+	SuitePrefix string `json:"suitePrefix,omitempty" yaml:"suitePrefix,omitempty"`
+	// SuiteIncludeAll and SuiteIncludeAny are like IncludeAll and IncludeAny, for
+	// substrings of the test's suite rather than its name, e.g. to route tests by a
+	// substring of the suite only. They're also case-insensitive with CaseInsensitive or
+	// an i/ prefix, but don't support alternations.
+	SuiteIncludeAll []string `json:"suiteIncludeAll,omitempty" yaml:"suiteIncludeAll,omitempty"`
+	SuiteIncludeAny []string `json:"suiteIncludeAny,omitempty" yaml:"suiteIncludeAny,omitempty"`
+	// ExcludeSuite forces a non-match when the test is in any of the listed suites, e.g.
+	// to keep a broad substring matcher from claiming another suite's tests.
+	ExcludeSuite []string `json:"excludeSuite,omitempty" yaml:"excludeSuite,omitempty"`
+	// Variants is a list of variants formatted as variantCategory:variantValue, all of
+	// which must be present on the test.
+	Variants []string `json:"variants,omitempty" yaml:"variants,omitempty"`
+	// Repo requires the test to come from the repository, formatted as org/name, e.g.
+	// openshift/origin. RepoAny matches tests from any of the listed repositories; when
+	// Repo is also set, the test must come from Repo as well. Leave both empty to match
+	// tests regardless of their repository, including tests whose repository isn't known.
+	Repo    string   `json:"repo,omitempty" yaml:"repo,omitempty"`
+	RepoAny []string `json:"repoAny,omitempty" yaml:"repoAny,omitempty"`
+	// NamePrefix and NameSuffix require the test name to begin or end with the given
+	// string, e.g. a NamePrefix of "[sig-network]" only matches tests whose name begins
+	// with that tag, not ones mentioning it elsewhere.
+	NamePrefix string `json:"namePrefix,omitempty" yaml:"namePrefix,omitempty"`
+	NameSuffix string `json:"nameSuffix,omitempty" yaml:"nameSuffix,omitempty"`
+	// Tags are bracketed tags the test name must carry, see util.ExtractTestTags. Each
+	// key must be present, along with all of its listed values, e.g. {"Feature":
+	// {"Idling"}} requires [Feature:Idling], and {"Disruptive": nil} requires [Disruptive].
+	Tags map[string][]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// Params are bracketed key=value parameters the test name must all carry, see
+	// util.ExtractTestParams, e.g. {"when": "upgrade"} requires [when=upgrade].
+	Params map[string]string `json:"params,omitempty" yaml:"params,omitempty"`
+	// RequireFlags are bracket flags the test name must all carry, e.g. ["Disruptive",
+	// "Serial"] requires both [Disruptive] and [Serial], regardless of the test's SIG.
+	RequireFlags []string `json:"requireFlags,omitempty" yaml:"requireFlags,omitempty"`
+	// Annotations are corpus-relative annotations the test must all carry, e.g.
+	// AnnotationLongName to route the tests with the longest names. They're only present
+	// once the corpus has been annotated, see AnnotateCorpus.
+	Annotations []string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	// IsDisruption requires the test to be a disruption test, see util.IsDisruptionTest,
+	// e.g. to move a namespace's disruption tests to the component likely to cause them.
+	IsDisruption bool `json:"isDisruption,omitempty" yaml:"isDisruption,omitempty"`
+	// Serial, when set, requires the test to run serially if true, or in parallel if
+	// false, see util.IsSerialTest.
+	Serial *bool `json:"serial,omitempty" yaml:"serial,omitempty"`
+	// NoSIG requires the test name to carry no SIG tag at all, see util.HasSIGTag, e.g. to
+	// route untagged tests to a triage owner. Scope it with the other conditions, e.g. a
+	// Suite; it can't be combined with SIG or SIGAny.
+	NoSIG bool `json:"noSIG,omitempty" yaml:"noSIG,omitempty"`
+	// TopLevelContains is a list of substrings that must all be in the first segment of
+	// the test name's hierarchy (see util.SplitTestHierarchy), e.g. "TestUpgrade" in
+	// "TestUpgrade/control-plane", so they can't match deep in a subtest's name.
+	TopLevelContains []string `json:"topLevelContains,omitempty" yaml:"topLevelContains,omitempty"`
+	// IncludeAll entries may be pipe-separated alternations, any one of which must be in
+	// the test name, so ["CSI|in-tree", "volumes"] requires "volumes" and either "CSI" or
+	// "in-tree". Each alternative may have its own "i/" prefix, see CaseInsensitive. Use
+	// IncludeAny or IncludeRegex to match a literal pipe.
+	IncludeAll []string `json:"includeAll,omitempty" yaml:"includeAll,omitempty"`
+	IncludeAny []string `json:"includeAny,omitempty" yaml:"includeAny,omitempty"`
+	// IncludeNone is a positive requirement that none of the substrings are in the test
+	// name. A test containing one is rejected just as it would be by ExcludeAny, the
+	// difference is in what the matcher claims: exclusions only narrow the other
+	// conditions, so a matcher with nothing but exclusions is almost certainly missing its
+	// inclusions and Lint warns about it, whereas IncludeNone on its own deliberately
+	// claims every test not mentioning any of the substrings.
+	IncludeNone []string `json:"includeNone,omitempty" yaml:"includeNone,omitempty"`
+	ExcludeAll  []string `json:"excludeAll,omitempty" yaml:"excludeAll,omitempty"`
+	ExcludeAny  []string `json:"excludeAny,omitempty" yaml:"excludeAny,omitempty"`
 
+	// IncludeWord is a list of words that must all be in the test name, like IncludeAll,
+	// but only as whole words: delimited by non-word characters or the ends of the name,
+	// so "dns" matches "the dns operator" and "dns-default", but not "windns".
+	IncludeWord []string `json:"includeWord,omitempty" yaml:"includeWord,omitempty"`
+	// ExcludeWord is a list of words that, like ExcludeAny, force a non-match if any of
+	// them are in the test name as a whole word.
+	ExcludeWord []string `json:"excludeWord,omitempty" yaml:"excludeWord,omitempty"`
+
+	// IncludeGlob is a list of shell-style glob patterns (see util.CompileGlob) matched
+	// against the whole test name. Like IncludeAll, all of them must match. For
+	// example, `*oc adm must-gather*` matches any test name containing "oc adm must-gather".
+	IncludeGlob []string `json:"includeGlob,omitempty" yaml:"includeGlob,omitempty"`
+	// PathGlob is a list of doublestar-style glob patterns (see util.CompilePathGlob),
+	// any of which must match the test's source file path, e.g. `test/e2e/storage/**`.
+	// Tests without a path never match.
+	PathGlob []string `json:"pathGlob,omitempty" yaml:"pathGlob,omitempty"`
+	// ExcludeGlob is a list of glob patterns that, like ExcludeAny, force a non-match
+	// if any of them match the test name.
+	ExcludeGlob []string `json:"excludeGlob,omitempty" yaml:"excludeGlob,omitempty"`
+	// IncludeRegex is a list of regular expressions that must all match the test name.
+	// Capabilities may refer to the capture groups of the first of them, e.g. a capability
+	// of "$1" with `operator (install|upgrade) should succeed` is "install" or "upgrade",
+	// depending on the test.
+	IncludeRegex []string `json:"includeRegex,omitempty" yaml:"includeRegex,omitempty"`
+	// NameMatchesRegex is a regular expression the whole test name must match, as if
+	// anchored with ^ and $, to catch structurally unusual names, e.g. auto-generated ones
+	// with no spaces. It's meant for last-resort routing to a triage bucket, so give the
+	// matcher PriorityCatchAll, or another negative priority, for it to only win when
+	// nothing else claims the test.
+	NameMatchesRegex string `json:"nameMatchesRegex,omitempty" yaml:"nameMatchesRegex,omitempty"`
+	// Number requires a number in the test name to meet a threshold, e.g. to claim tests
+	// that "should scale to" at least 50 pods.
+	Number *NumberCondition `json:"number,omitempty" yaml:"number,omitempty"`
+	// Expr is a boolean expression over the test, for rules the ANDed fields can't express,
+	// e.g. `(contains("ovn") AND sig("sig-network")) OR (suite("e2e") AND NOT
+	// contains("sdn"))`. Its predicates are contains("..."), following CaseInsensitive and
+	// i/ prefixes like IncludeAll, sig("...") and suite("..."), combined with AND, OR, NOT
+	// and parentheses. When set, it replaces the field-based conditions, so it can't be
+	// combined with them.
+	Expr string `json:"expr,omitempty" yaml:"expr,omitempty"`
+	// Negate inverts the matcher, so it claims the tests its conditions don't all hold for,
+	// e.g. every sig-network test without "ovn" in its name with SIG and IncludeAll. The
+	// conditions are inverted as a whole, exclusions included: a negated matcher claims the
+	// tests its exclusions reject, so use a separate matcher, or Expr, to exclude tests from
+	// a negated matcher's claims. Priority isn't affected, but as negated matchers tend to
+	// claim broadly, they're usually best given a negative priority, e.g. PriorityFallback,
+	// so more specific matches win. A negated matcher without conditions never matches, and
+	// one whose patterns are invalid doesn't either.
+	Negate bool `json:"negate,omitempty" yaml:"negate,omitempty"`
+
+	// MatchCleanName compares IncludeAll and IncludeAny to the test name without its
+	// bracketed tags, see util.StripTags, so substrings needn't account for tags like
+	// [sig-network][Feature:EgressIP] between words.
+	MatchCleanName bool `json:"matchCleanName,omitempty" yaml:"matchCleanName,omitempty"`
+
+	// CaseInsensitive makes the TopLevelContains, IncludeAll, IncludeAny, IncludeNone,
+	// ExcludeAll, ExcludeAny, IncludeWord and ExcludeWord comparisons ignore case. By
+	// default, substrings are matched exactly. Individual entries can instead be made
+	// case-insensitive with an "i/" prefix, e.g. "i/must-gather", leaving the other entries
+	// exact.
+	CaseInsensitive bool `json:"caseInsensitive,omitempty" yaml:"caseInsensitive,omitempty"`
+
+	// JiraProject overrides the component's DefaultJiraProject for the tests the matcher
+	// claims, see Component.JiraProjectFor.
+	JiraProject   string `json:"jiraProject,omitempty" yaml:"jiraProject,omitempty"`
+	JiraComponent string `json:"jiraComponent,omitempty" yaml:"jiraComponent,omitempty"`
+	// Capabilities are assigned to the tests the matcher claims. Entries containing $ are
+	// expanded against the first IncludeRegex's capture groups, see regexp.Regexp.Expand,
+	// and dropped if they expand to nothing; others are used as is. Referring to a group the
+	// regex doesn't have, or to any group from a negated matcher, is an error.
+	Capabilities []string `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+	// Priority decides ownership when multiple matches compete for a test, the highest
+	// wins. Unset, it's PriorityDefault; use more than the owning component's
+	// NamespacePriority, PriorityNamespace by default, to override namespace ownership.
+	// Priorities may be negative to defer to other matches: lower
+	// numbers are always weaker, so a matcher with PriorityCatchAll only wins when
+	// nothing with a higher priority, including an unset one, claims the test.
+	Priority int `json:"priority,omitempty" yaml:"priority,omitempty"`
+
+	// TemplateRef names one of the MatcherTemplates whose fields are merged into the
+	// matcher's when the component is compiled, see EffectiveMatchers: lists are combined,
+	// and the matcher's other fields override the template's. Only fields the matcher sets
+	// override, so a matcher can't reset a template's bool to false or its Priority to 0.
+	TemplateRef string `json:"templateRef,omitempty" yaml:"templateRef,omitempty"`
+
+	// EffectiveFrom and EffectiveUntil limit the matcher to the releases from EffectiveFrom
+	// up to, but not including, EffectiveUntil, so ownership can change at a release
+	// boundary, see FindMatchForRelease. Releases are dot-separated numbers, e.g. "4.14";
+	// either may be left empty for an open-ended range.
+	EffectiveFrom  string `json:"effectiveFrom,omitempty" yaml:"effectiveFrom,omitempty"`
+	EffectiveUntil string `json:"effectiveUntil,omitempty" yaml:"effectiveUntil,omitempty"`
+}
+
+// NumberCondition compares a number embedded in the test name to a threshold, e.g. the
+// 100 in "should scale to 100 pods". Tests the pattern doesn't match, or where what it
+// captures isn't a number, don't meet the condition.
+type NumberCondition struct {
+	// Pattern is a regular expression matched against the test name, with a named Number
+	// capture group around the number, e.g. `scale to (?P<Number>\d+) pods`.
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+
+	// Threshold is a comparison operator, one of <, <=, ==, !=, >= or >, followed by the
+	// number the captured number is compared to, e.g. ">= 50".
+	Threshold string `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+}
+
+// MatchSource identifies which stage of FindMatch claimed a test.
+type MatchSource string
+
+const (
+	// MatchSourceJiraField means the test carried a Jira field naming the component's
+	// default Jira component.
+	MatchSourceJiraField MatchSource = "jira-field"
+	// MatchSourceOperator means the test was recognized as one of the component's operator tests.
+	MatchSourceOperator MatchSource = "operator"
+	// MatchSourceMatcher means one of the component's Matchers matched the test.
+	MatchSourceMatcher MatchSource = "matcher"
+	// MatchSourceCustom means one of the component's CustomMatchers matched the test.
+	MatchSourceCustom MatchSource = "custom"
+	// MatchSourceSIG means the test belongs to one of the component's OwnsSIG SIGs.
+	MatchSourceSIG MatchSource = "sig"
+	// MatchSourceNamespace means the test references a namespace owned by the component.
+	MatchSourceNamespace MatchSource = "namespace"
+	// MatchSourceJiraRegex means the component's JiraComponentRegex found its Jira component
+	// in the test name.
+	MatchSourceJiraRegex MatchSource = "jira-regex"
+)
+
+// Confidence is how sure FindMatch is of an ownership claim, higher being more certain.
+// It's intended to help reviewers prioritize assignments that are likely wrong.
+type Confidence int
+
+const (
+	// ConfidenceNone is the confidence when there's no match.
+	ConfidenceNone Confidence = iota
+	// ConfidenceLow is for broad claims: OwnsSIG matches, and matchers with a single condition.
+	ConfidenceLow
+	// ConfidenceMedium is for namespace and custom matches, and matchers with two conditions.
+	ConfidenceMedium
+	// ConfidenceHigh is for tests naming the component's Jira component, operator tests, and
+	// matchers with three or more conditions.
+	ConfidenceHigh
+)
+
+// String returns the confidence's name, e.g. "high", or "" for ConfidenceNone.
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	}
+	return ""
+}
+
+// MarshalText serializes the confidence as its name.
+func (c Confidence) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// MatchReason describes why FindMatch claimed a test. It's intended to help debug
+// misassigned tests.
+type MatchReason struct {
+	// Source is the stage of FindMatch that claimed the test, empty when there was no match.
+	Source MatchSource
+
+	// MatcherIndex is the index in Matchers of the matcher that claimed the test, or in
+	// CustomMatchers for custom matches. It's only meaningful when Source is
+	// MatchSourceMatcher or MatchSourceCustom.
+	MatcherIndex int
+
+	// JiraComponent is the Jira field value found in the test name, for jira-field matches,
+	// or the value JiraComponentRegex captured, for jira-regex matches.
 	JiraComponent string
-	Capabilities  []string
-	Priority      int
+
+	// Operator is the operator the test was identified for, for operator matches.
+	Operator string
+
+	// Namespace is the namespace found in the test name, for namespace matches.
+	Namespace string
+
+	// SIGs, Suite, Variants and Substrings are the concrete matcher values that were
+	// satisfied, for matcher matches. SIGs is also the owned SIG, for sig matches.
+	SIGs       []string
+	Suite      string
+	Variants   []string
+	Substrings []string
+
+	// Confidence is how sure the claim is, based on Source and, for matcher matches, the
+	// matcher's Specificity.
+	Confidence Confidence
 }
 
+// FindMatch returns the matcher that claims the test, or nil if this component
+// doesn't own it.
 func (c *Component) FindMatch(test *v1.TestInfo) *ComponentMatcher {
-	jiraComponents := util.ExtractTestField(test.Name, "Jira")
-	for _, jc := range jiraComponents {
-		unquoted, err := strconv.Unquote(jc)
-		if err != nil { // not quoted
-			unquoted = jc
+	matcher, _ := c.FindMatchWithReason(test)
+	return matcher
+}
+
+// FindMatchContext is like FindMatch, but returns the context's error instead if it's
+// been cancelled or has timed out.
+func (c *Component) FindMatchContext(ctx context.Context, test *v1.TestInfo) (*ComponentMatcher, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.FindMatch(test), nil
+}
+
+// FindMatchWithReason is like FindMatch, but also returns a description of why
+// the test matched.
+func (c *Component) FindMatchWithReason(test *v1.TestInfo) (*ComponentMatcher, MatchReason) {
+	m, reason := c.findMatchWithReason(test, "")
+	if m != nil {
+		reason.Confidence = reason.confidence(m)
+	}
+	return m, reason
+}
+
+// confidence returns the confidence of the match claimed for the reason.
+func (r MatchReason) confidence(m *ComponentMatcher) Confidence {
+	switch r.Source {
+	case MatchSourceJiraField, MatchSourceJiraRegex, MatchSourceOperator:
+		return ConfidenceHigh
+	case MatchSourceNamespace, MatchSourceCustom:
+		return ConfidenceMedium
+	case MatchSourceMatcher:
+		if m.Negate {
+			// Claims of negated matchers are as broad as their conditions are narrow.
+			return ConfidenceLow
+		}
+		switch specificity := m.Specificity(); {
+		case specificity >= 3:
+			return ConfidenceHigh
+		case specificity == 2:
+			return ConfidenceMedium
 		}
+	}
+	return ConfidenceLow
+}
 
-		if strings.EqualFold(unquoted, c.DefaultJiraComponent) {
-			return &ComponentMatcher{
-				JiraComponent: c.DefaultJiraComponent,
-			}
+func (c *Component) findMatchWithReason(test *v1.TestInfo, release string) (*ComponentMatcher, MatchReason) {
+	// Only release-independent matches are cached, see MatchCacheSize.
+	cache := c.matchCache
+	if release != "" {
+		cache = nil
+	}
+	var key matchCacheKey
+	var generation uint64
+	if cache != nil {
+		key = newMatchCacheKey(test)
+		m, reason, gen, ok := cache.get(key)
+		if ok {
+			return m, reason
 		}
+		generation = gen
 	}
 
-	if ok, capabilities := c.IsOperatorTest(test); ok {
+	m, reason := c.findStageMatch(test, release)
+	if m != nil && len(c.DefaultCapabilities) > 0 {
+		// The matcher may be shared, e.g. returned by a custom matcher, so it's copied.
+		withDefaults := *m
+		withDefaults.Capabilities = mergeCapabilities(m.Capabilities, c.DefaultCapabilities)
+		m = &withDefaults
+	}
+	if cache != nil {
+		cache.add(key, generation, m, reason)
+	}
+	return m, reason
+}
+
+// findStageMatch returns the match from the first of FindMatch's stages to claim the test.
+func (c *Component) findStageMatch(test *v1.TestInfo, release string) (*ComponentMatcher, MatchReason) {
+	stages := c.stages()
+
+	start := MatchMetrics.start()
+	jiraComponent, ok := c.findJiraField(test)
+	MatchMetrics.done(MatchStageJira, start)
+	if ok {
 		return &ComponentMatcher{
 			JiraComponent: c.DefaultJiraComponent,
-			Capabilities:  capabilities,
+			Priority:      PriorityDefault,
+		}, MatchReason{
+			Source:        MatchSourceJiraField,
+			JiraComponent: jiraComponent,
 		}
 	}
 
-	// Check if any of the Matchers match the given test
-	for _, m := range c.Matchers {
-		sigMatch := true
-		suiteMatch := true
-		incSubstrMatch := true
-		incAnySubstrMatch := true
+	matching := c.matchingTest(test)
 
-		if m.SIG != "" {
-			sigMatch = util.IsSigTest(test.Name, m.SIG)
+	// The operator and namespace stages are skipped for components without operators or
+	// namespaces, so substring-only components go straight to the matchers.
+	if stages.operators {
+		start = MatchMetrics.start()
+		operator, capabilities := c.identifyOperatorTest(test)
+		MatchMetrics.done(MatchStageOperator, start)
+		if operator != "" {
+			// Capabilities from a matcher that also matches the test are merged with the
+			// operator's capabilities.
+			if _, m := c.findMatcher(matching, release); m != nil {
+				capabilities = mergeCapabilities(capabilities, m.Capabilities)
+			}
+			return &ComponentMatcher{
+				JiraComponent: c.DefaultJiraComponent,
+				Capabilities:  capabilities,
+				Priority:      PriorityDefault,
+			}, MatchReason{
+				Source:   MatchSourceOperator,
+				Operator: operator,
+			}
 		}
+	}
+
+	start = MatchMetrics.start()
+	m, reason := c.findMatchFromMatchers(matching, release)
+	MatchMetrics.done(MatchStageMatchers, start)
+	if m != nil {
+		return m, reason
+	}
 
-		if m.Suite != "" {
-			suiteMatch = m.IsSuiteTest(test)
+	if stages.namespaces {
+		start = MatchMetrics.start()
+		m, reason = c.findNamespaceMatch(test)
+		MatchMetrics.done(MatchStageNamespace, start)
+		if m != nil {
+			return m, reason
 		}
+	}
+
+	if stages.jiraRegex {
+		start = MatchMetrics.start()
+		m, reason = c.findJiraRegexMatch(test)
+		MatchMetrics.done(MatchStageJiraRegex, start)
+	}
+	return m, reason
+}
+
+// findJiraRegexMatch is the fallback stage of FindMatchWithReason, claiming tests whose
+// name JiraComponentRegex finds the component's Jira component in.
+func (c *Component) findJiraRegexMatch(test *v1.TestInfo) (*ComponentMatcher, MatchReason) {
+	re := c.jiraComponentRegex()
+	if re == nil {
+		return nil, MatchReason{}
+	}
+	submatches := re.FindStringSubmatch(test.Name)
+	if submatches == nil {
+		return nil, MatchReason{}
+	}
+	jiraComponent := strings.TrimSpace(submatches[re.SubexpIndex("Component")])
+	if jiraComponent == "" || !c.isDefaultJiraComponent(jiraComponent) {
+		return nil, MatchReason{}
+	}
+	return &ComponentMatcher{
+		JiraComponent: c.DefaultJiraComponent,
+		Priority:      PriorityDefault,
+	}, MatchReason{
+		Source:        MatchSourceJiraRegex,
+		JiraComponent: jiraComponent,
+	}
+}
 
-		if len(m.IncludeAll) > 0 {
-			incSubstrMatch = m.IsSubstringAllTest(m.IncludeAll, test)
+// findJiraField returns the test's Jira field naming the component's DefaultJiraComponent,
+// or one of its Aliases, unquoted, and whether it has one. A test with any Jira field in
+// ExcludeJiraComponents has none.
+func (c *Component) findJiraField(test *v1.TestInfo) (string, bool) {
+	if !mayHaveJiraField(test.Name) {
+		return "", false
+	}
+	field, found := "", false
+	for _, jc := range util.ExtractTestField(test.Name, "Jira") {
+		unquoted := util.UnquoteField(jc)
+		if c.isExcludedJiraComponent(unquoted) {
+			return "", false
 		}
-		if len(m.IncludeAny) > 0 {
-			incAnySubstrMatch = m.IsSubstringAnyTest(m.IncludeAny, test)
+		if !found && c.isDefaultJiraComponent(unquoted) {
+			field, found = unquoted, true
 		}
+	}
+	return field, found
+}
 
-		if len(m.ExcludeAll) > 0 {
-			// If all the exclusions are present, we force a non-match
-			if m.IsSubstringAllTest(m.ExcludeAll, test) {
-				continue
-			}
-		}
-		if len(m.ExcludeAny) > 0 {
-			// If any of the exclusions are present, we force a non-match
-			if m.IsSubstringAnyTest(m.ExcludeAny, test) {
-				continue
-			}
+// isDefaultJiraComponent reports whether the Jira component, compared case-insensitively,
+// is the component's DefaultJiraComponent or one of its Aliases.
+func (c *Component) isDefaultJiraComponent(jiraComponent string) bool {
+	if strings.EqualFold(jiraComponent, c.DefaultJiraComponent) {
+		return true
+	}
+	for _, alias := range c.Aliases {
+		if strings.EqualFold(jiraComponent, alias) {
+			return true
 		}
+	}
+	return false
+}
 
-		// AND the match results together
-		if sigMatch && suiteMatch && incSubstrMatch && incAnySubstrMatch {
-			return &m
+// isExcludedJiraComponent reports whether the Jira field value is one of the component's
+// ExcludeJiraComponents.
+func (c *Component) isExcludedJiraComponent(jiraComponent string) bool {
+	for _, excluded := range c.ExcludeJiraComponents {
+		if strings.EqualFold(jiraComponent, excluded) {
+			return true
 		}
 	}
+	return false
+}
 
+// findNamespaceMatch is the last stage of FindMatchWithReason, claiming tests in the
+// component's namespaces.
+func (c *Component) findNamespaceMatch(test *v1.TestInfo) (*ComponentMatcher, MatchReason) {
 	// Namespace ownership is last to allow specifically overriding a test's ownership.
 	// For example, ns/console disruption tests are moved to router, because it's much more
 	// likely to be an ingress problem. Components must still force their priority higher than
-	// namespace ownership to override.
-	if namespace, ok := c.IsNamespaceTest(test.Name); ok {
+	// namespace ownership to override, e.g. with an IsDisruption matcher above the owning
+	// component's NamespacePriority.
+	// A test referencing several namespaces is claimed if any of them are owned.
+	for _, namespace := range c.namespacesInTest(test.Name) {
 		if c.IsInNamespace(namespace) {
 			return &ComponentMatcher{
 				JiraComponent: c.DefaultJiraComponent,
-				Priority:      10,
+				Priority:      c.namespacePriority(),
+			}, MatchReason{
+				Source:    MatchSourceNamespace,
+				Namespace: namespace,
+			}
+		}
+	}
+
+	return nil, MatchReason{}
+}
+
+// namespacePriority returns the priority of the component's namespace ownership matches.
+func (c *Component) namespacePriority() int {
+	if c.NamespacePriority == 0 {
+		return PriorityNamespace
+	}
+	return c.NamespacePriority
+}
+
+// findMatchFromMatchers is the part of FindMatchWithReason after the Jira field and
+// operator stages, that looks for a match from the Matchers, CustomMatchers or OwnsSIG.
+func (c *Component) findMatchFromMatchers(test *v1.TestInfo, release string) (*ComponentMatcher, MatchReason) {
+	if i, m := c.findMatcher(test, release); m != nil {
+		if m.Negate {
+			// A negated matcher claims the test for the values it doesn't match, so
+			// there are none to report.
+			return m, MatchReason{Source: MatchSourceMatcher, MatcherIndex: i}
+		}
+		return m, MatchReason{
+			Source:       MatchSourceMatcher,
+			MatcherIndex: i,
+			SIGs:         m.matchedSIGs(test),
+			Suite:        m.matchedSuite(test),
+			Variants:     m.Variants,
+			Substrings:   m.matchedSubstrings(test),
+		}
+	}
+
+	for i, custom := range c.CustomMatchers {
+		if m := custom(test); m != nil {
+			return m, MatchReason{
+				Source:       MatchSourceCustom,
+				MatcherIndex: i,
+			}
+		}
+	}
+
+	for _, sig := range c.OwnsSIG {
+		if util.IsSigTest(test.Name, sig) {
+			return &ComponentMatcher{
+				JiraComponent: c.DefaultJiraComponent,
+				Priority:      c.OwnsSIGPriority,
+			}, MatchReason{
+				Source: MatchSourceSIG,
+				SIGs:   []string{sig},
 			}
 		}
+	}
+
+	return nil, MatchReason{}
+}
+
+// mayHaveJiraField is a cheap check for whether the test name could have a Jira field,
+// which must contain "jira" in any case, to avoid extracting fields from most tests.
+func mayHaveJiraField(testName string) bool {
+	for i := 0; i+4 <= len(testName); i++ {
+		if testName[i]|0x20 == 'j' && testName[i+1]|0x20 == 'i' && testName[i+2]|0x20 == 'r' && testName[i+3]|0x20 == 'a' {
+			return true
+		}
+	}
+	return false
+}
+
+// findMatcher returns the component's Matcher with the highest priority among those active
+// in the release that match the test, and its index, or nil if none match. When several
+// matching matchers share the highest priority, the first in Matchers wins.
+func (c *Component) findMatcher(test *v1.TestInfo, release string) (int, *ComponentMatcher) {
+	matchers := c.EffectiveMatchers()
+	best := -1
+	for i := range matchers {
+		if !matchers[i].activeIn(release) {
+			continue
+		}
+		if best >= 0 && matchers[i].EffectivePriority() <= matchers[best].EffectivePriority() {
+			continue
+		}
+		if matchers[i].matches(test, c.compiledMatcher(i)) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return -1, nil
+	}
+	m := matchers[best]
+	if compiled := c.compiledMatcher(best); compiled.dynamicCapabilities {
+		m.Capabilities = compiled.expandCapabilities(m.Capabilities, test.Name)
+	}
+	return best, &m
+}
+
+// mergeCapabilities returns the sorted union of the capability lists, or nil if they're
+// all empty.
+func mergeCapabilities(lists ...[]string) []string {
+	merged := sets.New[string]()
+	for _, list := range lists {
+		merged.Insert(list...)
+	}
+	if merged.Len() == 0 {
 		return nil
 	}
+	return sets.List(merged)
+}
 
-	return nil
+// matches reports whether all the conditions set on the matcher hold for the test, or
+// don't if it's negated. A nil compiled matcher means the matcher's patterns are invalid,
+// and never matches.
+func (cm *ComponentMatcher) matches(test *v1.TestInfo, compiled *compiledMatcher) bool {
+	if compiled == nil {
+		return false
+	}
+	return cm.conditionsHold(test, compiled) != cm.Negate
+}
+
+// conditionsHold reports whether all the conditions set on the matcher hold for the test,
+// regardless of Negate.
+func (cm *ComponentMatcher) conditionsHold(test *v1.TestInfo, compiled *compiledMatcher) bool {
+	var e conditionEvaluator
+	cm.evaluateConditions(test, compiled, &e)
+	return !e.failed
+}
+
+// conditionEvaluator collects the conditions of a matcher that fail for a test. By default
+// it only records that one failed, which is all conditionsHold needs, and
+// evaluateConditions stops at the first. With all set, it keeps going and collects every
+// failure with its reason, for ExplainNonMatch.
+type conditionEvaluator struct {
+	all      bool
+	failed   bool
+	failures []ConditionFailure
+}
+
+// fail records that the condition on the field failed, and reports whether evaluation
+// should stop. The reason is only formatted when every failure is collected.
+func (e *conditionEvaluator) fail(field string, reason func() string) bool {
+	e.failed = true
+	if !e.all {
+		return true
+	}
+	e.failures = append(e.failures, ConditionFailure{Field: field, Reason: reason()})
+	return false
+}
+
+// evaluateConditions checks the conditions set on the matcher against the test, regardless
+// of Negate, reporting those that fail to the evaluator.
+func (cm *ComponentMatcher) evaluateConditions(test *v1.TestInfo, compiled *compiledMatcher, e *conditionEvaluator) {
+	if compiled.expr != nil {
+		if !compiled.expr.eval(test) {
+			e.fail("expr", func() string { return fmt.Sprintf("%q is false", cm.Expr) })
+		}
+		return
+	}
+
+	if cm.SIG != "" && !util.IsSigTest(test.Name, cm.SIG) &&
+		e.fail("sig", func() string { return fmt.Sprintf("test isn't in %q", cm.SIG) }) {
+		return
+	}
+
+	if len(cm.SIGAny) > 0 && cm.matchedSIGAny(test) == "" &&
+		e.fail("sigAny", func() string { return fmt.Sprintf("test isn't in any of %q", cm.SIGAny) }) {
+		return
+	}
+
+	// If the test belongs to any excluded SIG, we force a non-match
+	for _, sig := range cm.ExcludeSIG {
+		if util.IsSigTest(test.Name, sig) &&
+			e.fail("excludeSIG", func() string { return fmt.Sprintf("test is in excluded %q", sig) }) {
+			return
+		}
+	}
+
+	if cm.Suite != "" && !cm.IsSuiteTest(test) &&
+		e.fail("suite", func() string { return fmt.Sprintf("suite %q isn't %q", test.Suite, cm.Suite) }) {
+		return
+	}
+
+	if len(cm.SuiteAny) > 0 && !cm.IsSuiteAnyTest(test) &&
+		e.fail("suiteAny", func() string { return fmt.Sprintf("suite %q isn't any of %q", test.Suite, cm.SuiteAny) }) {
+		return
+	}
+
+	if cm.SuitePrefix != "" && !strings.HasPrefix(test.Suite, cm.SuitePrefix) &&
+		e.fail("suitePrefix", func() string { return fmt.Sprintf("suite %q doesn't begin with %q", test.Suite, cm.SuitePrefix) }) {
+		return
+	}
+
+	if len(compiled.suiteIncludeAll) > 0 || len(compiled.suiteIncludeAny) > 0 {
+		suite := compiled.suiteName(test)
+		for i, str := range compiled.suiteIncludeAll {
+			if !str.in(suite) &&
+				e.fail("suiteIncludeAll", func() string {
+					return fmt.Sprintf("suite %q is missing substring %q", test.Suite, cm.SuiteIncludeAll[i])
+				}) {
+				return
+			}
+		}
+		if len(compiled.suiteIncludeAny) > 0 && !containsAny(suite, compiled.suiteIncludeAny) &&
+			e.fail("suiteIncludeAny", func() string { return fmt.Sprintf("suite %q has none of %q", test.Suite, cm.SuiteIncludeAny) }) {
+			return
+		}
+	}
+
+	// If the test is in any excluded suite, we force a non-match
+	for _, suite := range cm.ExcludeSuite {
+		if test.Suite == suite &&
+			e.fail("excludeSuite", func() string { return fmt.Sprintf("suite %q is excluded", suite) }) {
+			return
+		}
+	}
+
+	if len(cm.Variants) > 0 {
+		testVariants := sets.New[string](test.Variants...)
+		for _, variant := range cm.Variants {
+			if !testVariants.Has(variant) &&
+				e.fail("variants", func() string { return fmt.Sprintf("missing variant %q", variant) }) {
+				return
+			}
+		}
+	}
+
+	if cm.Repo != "" && test.Repo != cm.Repo &&
+		e.fail("repo", func() string { return fmt.Sprintf("repo %q isn't %q", test.Repo, cm.Repo) }) {
+		return
+	}
+
+	if len(cm.RepoAny) > 0 && !cm.IsRepoAnyTest(test) &&
+		e.fail("repoAny", func() string { return fmt.Sprintf("repo %q isn't any of %q", test.Repo, cm.RepoAny) }) {
+		return
+	}
+
+	if len(cm.Tags) > 0 {
+		tags := util.ExtractTestTags(test.Name)
+		for _, key := range sets.List(sets.KeySet(cm.Tags)) {
+			testValues, ok := tags[key]
+			if !ok {
+				if e.fail("tags", func() string { return fmt.Sprintf("missing tag [%s]", key) }) {
+					return
+				}
+				continue
+			}
+			values := sets.New[string](testValues...)
+			for _, value := range cm.Tags[key] {
+				if !values.Has(value) &&
+					e.fail("tags", func() string { return fmt.Sprintf("missing tag [%s:%s]", key, value) }) {
+					return
+				}
+			}
+		}
+	}
+
+	if len(cm.Params) > 0 {
+		params := util.ExtractTestParams(test.Name)
+		for _, key := range sets.List(sets.KeySet(cm.Params)) {
+			if value, ok := params[key]; (!ok || value != cm.Params[key]) &&
+				e.fail("params", func() string { return fmt.Sprintf("missing param [%s=%s]", key, cm.Params[key]) }) {
+				return
+			}
+		}
+	}
+
+	if len(cm.RequireFlags) > 0 {
+		tags := util.ExtractTestTags(test.Name)
+		for _, flag := range cm.RequireFlags {
+			if _, ok := tags[flag]; !ok &&
+				e.fail("requireFlags", func() string { return fmt.Sprintf("missing flag [%s]", flag) }) {
+				return
+			}
+		}
+	}
+
+	if len(cm.Annotations) > 0 {
+		annotations := sets.New[string](test.Annotations...)
+		for _, annotation := range cm.Annotations {
+			if !annotations.Has(annotation) &&
+				e.fail("annotations", func() string { return fmt.Sprintf("missing annotation %q", annotation) }) {
+				return
+			}
+		}
+	}
+
+	if cm.IsDisruption && !util.IsDisruptionTest(test.Name) &&
+		e.fail("isDisruption", func() string { return "not a disruption test" }) {
+		return
+	}
+
+	if cm.Serial != nil && util.IsSerialTest(test) != *cm.Serial &&
+		e.fail("serial", func() string {
+			if *cm.Serial {
+				return "test runs in parallel"
+			}
+			return "test runs serially"
+		}) {
+		return
+	}
+
+	if cm.NoSIG && util.HasSIGTag(test.Name) &&
+		e.fail("noSIG", func() string { return "test has a sig tag" }) {
+		return
+	}
+
+	if cm.NamePrefix != "" && !strings.HasPrefix(test.Name, cm.NamePrefix) &&
+		e.fail("namePrefix", func() string { return fmt.Sprintf("name doesn't begin with %q", cm.NamePrefix) }) {
+		return
+	}
+
+	if cm.NameSuffix != "" && !strings.HasSuffix(test.Name, cm.NameSuffix) &&
+		e.fail("nameSuffix", func() string { return fmt.Sprintf("name doesn't end with %q", cm.NameSuffix) }) {
+		return
+	}
+
+	name := compiled.testName(test)
+	if len(compiled.topLevelContains) > 0 {
+		topLevel := compiled.topLevelName(test)
+		for i, str := range compiled.topLevelContains {
+			if !str.in(topLevel) &&
+				e.fail("topLevelContains", func() string {
+					return fmt.Sprintf("top-level name is missing substring %q", cm.TopLevelContains[i])
+				}) {
+				return
+			}
+		}
+	}
+
+	includeName := name
+	if cm.MatchCleanName && (len(compiled.includeAll) > 0 || len(compiled.includeAny) > 0) {
+		includeName = compiled.cleanName(test)
+	}
+
+	for i, alternatives := range compiled.includeAll {
+		if !containsAny(includeName, alternatives) &&
+			e.fail("includeAll", func() string { return fmt.Sprintf("missing substring %q", cm.IncludeAll[i]) }) {
+			return
+		}
+	}
+
+	if len(compiled.includeAny) > 0 && !containsAny(includeName, compiled.includeAny) &&
+		e.fail("includeAny", func() string { return fmt.Sprintf("has none of the substrings %q", cm.IncludeAny) }) {
+		return
+	}
+
+	for i, str := range compiled.includeNone {
+		if str.in(name) &&
+			e.fail("includeNone", func() string { return fmt.Sprintf("has substring %q", cm.IncludeNone[i]) }) {
+			return
+		}
+	}
+
+	for i, word := range compiled.includeWords {
+		if !word.MatchString(test.Name) &&
+			e.fail("includeWord", func() string { return fmt.Sprintf("missing word %q", cm.IncludeWord[i]) }) {
+			return
+		}
+	}
+
+	for i, glob := range compiled.includeGlobs {
+		if !glob.MatchString(test.Name) &&
+			e.fail("includeGlob", func() string { return fmt.Sprintf("doesn't match glob %q", cm.IncludeGlob[i]) }) {
+			return
+		}
+	}
+
+	if len(compiled.pathGlobs) > 0 && !matchesAnyGlob(compiled.pathGlobs, test.Path) &&
+		e.fail("pathGlob", func() string { return fmt.Sprintf("path %q doesn't match any of %q", test.Path, cm.PathGlob) }) {
+		return
+	}
+
+	// If all the exclusions are present, we force a non-match
+	if len(compiled.excludeAll) > 0 && containsAll(name, compiled.excludeAll) &&
+		e.fail("excludeAll", func() string { return fmt.Sprintf("excluded by all of %q", cm.ExcludeAll) }) {
+		return
+	}
+
+	// If any of the exclusions are present, we force a non-match
+	for i, str := range compiled.excludeAny {
+		if str.in(name) &&
+			e.fail("excludeAny", func() string { return fmt.Sprintf("excluded by %q", cm.ExcludeAny[i]) }) {
+			return
+		}
+	}
+
+	for i, word := range compiled.excludeWords {
+		if word.MatchString(test.Name) &&
+			e.fail("excludeWord", func() string { return fmt.Sprintf("excluded by word %q", cm.ExcludeWord[i]) }) {
+			return
+		}
+	}
+
+	for i, glob := range compiled.excludeGlobs {
+		if glob.MatchString(test.Name) &&
+			e.fail("excludeGlob", func() string { return fmt.Sprintf("excluded by glob %q", cm.ExcludeGlob[i]) }) {
+			return
+		}
+	}
+
+	for i, re := range compiled.includeRegexes {
+		if !re.MatchString(test.Name) &&
+			e.fail("includeRegex", func() string { return fmt.Sprintf("doesn't match %q", cm.IncludeRegex[i]) }) {
+			return
+		}
+	}
+
+	if compiled.nameRegex != nil && !compiled.nameRegex.MatchString(test.Name) &&
+		e.fail("nameMatchesRegex", func() string { return fmt.Sprintf("name doesn't match %q", cm.NameMatchesRegex) }) {
+		return
+	}
+
+	if compiled.number != nil && !compiled.number.matches(test.Name) &&
+		e.fail("number", func() string {
+			return fmt.Sprintf("no number matching %q meets %q", cm.Number.Pattern, cm.Number.Threshold)
+		}) {
+		return
+	}
+}
+
+// matchedSIGAny returns the first SIG in SIGAny that the test belongs to.
+func (cm *ComponentMatcher) matchedSIGAny(test *v1.TestInfo) string {
+	for _, sig := range cm.SIGAny {
+		if util.IsSigTest(test.Name, sig) {
+			return sig
+		}
+	}
+	return ""
+}
+
+// matchedSIGs returns the SIGs from SIG and SIGAny that the test belongs to.
+func (cm *ComponentMatcher) matchedSIGs(test *v1.TestInfo) []string {
+	var sigs []string
+	if cm.SIG != "" {
+		sigs = append(sigs, cm.SIG)
+	}
+	if sig := cm.matchedSIGAny(test); sig != "" {
+		sigs = append(sigs, sig)
+	}
+	return sigs
+}
+
+// matchedSuite returns the test's suite if the matcher requires one.
+func (cm *ComponentMatcher) matchedSuite(test *v1.TestInfo) string {
+	if cm.Suite != "" || len(cm.SuiteAny) > 0 || cm.SuitePrefix != "" {
+		return test.Suite
+	}
+	return ""
+}
+
+// matchedSubstrings returns the IncludeAll alternatives and IncludeAny substrings found in
+// the test name.
+func (cm *ComponentMatcher) matchedSubstrings(test *v1.TestInfo) []string {
+	var substrings []string
+	var candidates []string
+	for _, entry := range cm.IncludeAll {
+		candidates = append(candidates, splitAlternation(entry)...)
+	}
+	name := cm.includeName(test.Name)
+	for _, str := range append(candidates, cm.IncludeAny...) {
+		if cm.containsSubstring(name, str) {
+			substrings = append(substrings, str)
+		}
+	}
+	return substrings
+}
+
+// JiraComponents returns the sorted, de-duplicated list of every Jira component the
+// component can assign: DefaultJiraComponent and each matcher's JiraComponent. Aliases
+// aren't included, as they're never assigned, see HasJiraComponent.
+func (c *Component) JiraComponents() []string {
+	components := sets.New[string]()
+	if c.DefaultJiraComponent != "" {
+		components.Insert(c.DefaultJiraComponent)
+	}
+	for _, m := range c.EffectiveMatchers() {
+		if m.JiraComponent != "" {
+			components.Insert(m.JiraComponent)
+		}
+	}
+	return sets.List(components)
+}
+
+// HasJiraComponent reports whether the component assigns the Jira component, see
+// JiraComponents, or it's one of the Aliases of its DefaultJiraComponent.
+func (c *Component) HasJiraComponent(name string) bool {
+	for _, alias := range c.Aliases {
+		if alias == name {
+			return true
+		}
+	}
+	for _, jiraComponent := range c.JiraComponents() {
+		if jiraComponent == name {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *Component) ListNamespaces() []string {
@@ -144,18 +1303,158 @@ func (c *Component) IsInNamespace(testNamespace string) bool {
 	return false
 }
 
+// IsNamespaceTest returns the first namespace referenced by the test, if any.
 func (c *Component) IsNamespaceTest(testName string) (string, bool) {
-	testNamespace := ExtractNamespaceFromTestName(testName)
-	return testNamespace, len(testNamespace) > 0
+	if namespaces := c.namespacesInTest(testName); len(namespaces) > 0 {
+		return namespaces[0], true
+	}
+	return "", false
+}
+
+// namespacesInTest returns every namespace referenced by the test, using both the default
+// and the component's own namespace patterns.
+func (c *Component) namespacesInTest(testName string) []string {
+	patterns := append([]*regexp.Regexp{}, defaultNamespacePatterns()...)
+	for i := range c.NamespacePatterns {
+		if re := c.compiledNamespacePattern(i); re != nil {
+			patterns = append(patterns, re)
+		}
+	}
+	return extractNamespaces(testName, patterns)
 }
 
 func (cm *ComponentMatcher) IsSuiteTest(test *v1.TestInfo) bool {
 	return test.Suite == cm.Suite
 }
 
+// IsSuiteAnyTest reports whether the test is in any of the matcher's SuiteAny suites.
+func (cm *ComponentMatcher) IsSuiteAnyTest(test *v1.TestInfo) bool {
+	for _, suite := range cm.SuiteAny {
+		if test.Suite == suite {
+			return true
+		}
+	}
+	return false
+}
+
+// EffectivePriority returns the priority used to decide between competing matches,
+// where the highest value wins.
+func (cm *ComponentMatcher) EffectivePriority() int {
+	return cm.Priority
+}
+
+// Specificity returns the number of conditions set on the matcher. It's used to break
+// ties between matchers with equal priority, a matcher with more conditions being more
+// specific.
+func (cm *ComponentMatcher) Specificity() int {
+	specificity := 0
+	for _, set := range []bool{
+		cm.SIG != "",
+		len(cm.SIGAny) > 0,
+		len(cm.ExcludeSIG) > 0,
+		cm.Suite != "",
+		len(cm.SuiteAny) > 0,
+		cm.SuitePrefix != "",
+		len(cm.SuiteIncludeAll) > 0,
+		len(cm.SuiteIncludeAny) > 0,
+		len(cm.ExcludeSuite) > 0,
+		len(cm.Variants) > 0,
+		cm.Repo != "",
+		len(cm.RepoAny) > 0,
+		cm.NamePrefix != "",
+		cm.NameSuffix != "",
+		len(cm.TopLevelContains) > 0,
+		len(cm.Tags) > 0,
+		len(cm.Params) > 0,
+		len(cm.RequireFlags) > 0,
+		len(cm.Annotations) > 0,
+		cm.IsDisruption,
+		cm.Serial != nil,
+		cm.NoSIG,
+		len(cm.IncludeAll) > 0,
+		len(cm.IncludeAny) > 0,
+		len(cm.IncludeNone) > 0,
+		len(cm.ExcludeAll) > 0,
+		len(cm.ExcludeAny) > 0,
+		len(cm.IncludeWord) > 0,
+		len(cm.ExcludeWord) > 0,
+		len(cm.IncludeGlob) > 0,
+		len(cm.PathGlob) > 0,
+		len(cm.IncludeRegex) > 0,
+		cm.NameMatchesRegex != "",
+		cm.Number != nil,
+		len(cm.ExcludeGlob) > 0,
+		cm.Expr != "",
+	} {
+		if set {
+			specificity++
+		}
+	}
+	return specificity
+}
+
+// IsRepoAnyTest reports whether the test comes from any of the matcher's RepoAny
+// repositories.
+func (cm *ComponentMatcher) IsRepoAnyTest(test *v1.TestInfo) bool {
+	for _, repo := range cm.RepoAny {
+		if test.Repo == repo {
+			return true
+		}
+	}
+	return false
+}
+
+// IsParamTest reports whether the test name carries all of the matcher's Params.
+func (cm *ComponentMatcher) IsParamTest(test *v1.TestInfo) bool {
+	params := util.ExtractTestParams(test.Name)
+	for key, value := range cm.Params {
+		if testValue, ok := params[key]; !ok || testValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// IsTagTest reports whether the test name carries all of the matcher's Tags.
+func (cm *ComponentMatcher) IsTagTest(test *v1.TestInfo) bool {
+	tags := util.ExtractTestTags(test.Name)
+	for key, values := range cm.Tags {
+		testValues, ok := tags[key]
+		if !ok || !sets.New[string](testValues...).HasAll(values...) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFlagTest reports whether the test name carries all of the matcher's RequireFlags.
+func (cm *ComponentMatcher) IsFlagTest(test *v1.TestInfo) bool {
+	tags := util.ExtractTestTags(test.Name)
+	for _, flag := range cm.RequireFlags {
+		if _, ok := tags[flag]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAnnotatedTest reports whether the test carries all of the matcher's Annotations.
+func (cm *ComponentMatcher) IsAnnotatedTest(test *v1.TestInfo) bool {
+	return sets.New[string](test.Annotations...).HasAll(cm.Annotations...)
+}
+
+// IsVariantTest reports whether the test ran on all of the matcher's variants.
+func (cm *ComponentMatcher) IsVariantTest(test *v1.TestInfo) bool {
+	testVariants := sets.New[string](test.Variants...)
+	return testVariants.HasAll(cm.Variants...)
+}
+
+// IsSubstringAllTest reports whether the test name contains all of the substrings, or for
+// pipe-separated alternations, e.g. "CSI|in-tree", any one of their alternatives. Like
+// IsSubstringAnyTest, it compares the name without its tags if MatchCleanName is set.
 func (cm *ComponentMatcher) IsSubstringAllTest(allOf []string, test *v1.TestInfo) bool {
-	for _, str := range allOf {
-		if !strings.Contains(test.Name, str) {
+	for _, entry := range allOf {
+		if !cm.IsSubstringAnyTest(splitAlternation(entry), test) {
 			return false
 		}
 	}
@@ -163,23 +1462,119 @@ func (cm *ComponentMatcher) IsSubstringAllTest(allOf []string, test *v1.TestInfo
 }
 
 func (cm *ComponentMatcher) IsSubstringAnyTest(anyOf []string, test *v1.TestInfo) bool {
+	name := cm.includeName(test.Name)
 	for _, str := range anyOf {
-		if strings.Contains(test.Name, str) {
+		if cm.containsSubstring(name, str) {
 			return true
 		}
 	}
 	return false
 }
 
+// includeName returns the test name IncludeAll and IncludeAny are compared to, see
+// MatchCleanName.
+func (cm *ComponentMatcher) includeName(testName string) string {
+	if cm.MatchCleanName {
+		return util.StripTags(testName)
+	}
+	return testName
+}
+
+func (cm *ComponentMatcher) containsSubstring(testName, substr string) bool {
+	substr, fold := parseSubstring(substr, cm.CaseInsensitive)
+	if fold {
+		return strings.Contains(strings.ToLower(testName), strings.ToLower(substr))
+	}
+	return strings.Contains(testName, substr)
+}
+
+// caseInsensitivePrefix marks an IncludeAll, IncludeAny, IncludeNone, ExcludeAll or
+// ExcludeAny entry as case-insensitive, e.g. "i/etcd" matches "etcd" and "Etcd".
+const caseInsensitivePrefix = "i/"
+
+// parseSubstring returns a substring entry without its case-insensitive prefix, and
+// whether it's compared case-insensitively, either because of the prefix or because
+// the matcher is CaseInsensitive.
+func parseSubstring(str string, caseInsensitive bool) (string, bool) {
+	if strings.HasPrefix(str, caseInsensitivePrefix) {
+		return strings.TrimPrefix(str, caseInsensitivePrefix), true
+	}
+	return str, caseInsensitive
+}
+
 func (c *Component) IsOperatorTest(test *v1.TestInfo) (bool, []string) {
-	for _, operator := range c.Operators {
+	operator, capabilities := c.identifyOperatorTest(test)
+	return operator != "", capabilities
+}
+
+// identifyOperatorTest returns the operator a test is for and the capabilities
+// derived from it, or an empty operator if it isn't an operator test.
+func (c *Component) identifyOperatorTest(test *v1.TestInfo) (string, []string) {
+	operator, capabilities := c.identifyOperator(test)
+	if operator == "" {
+		return "", nil
+	}
+	if extra := c.operatorCapabilities(operator); len(extra) > 0 {
+		capabilities = mergeCapabilities(capabilities, extra)
+	}
+	return operator, capabilities
+}
+
+// operators returns the names of the operators in Operators and OperatorConfigs.
+func (c *Component) operators() []string {
+	if len(c.OperatorConfigs) == 0 {
+		return c.Operators
+	}
+
+	operators := append([]string{}, c.Operators...)
+	seen := sets.New[string](c.Operators...)
+	for _, config := range c.OperatorConfigs {
+		if !seen.Has(config.Name) {
+			seen.Insert(config.Name)
+			operators = append(operators, config.Name)
+		}
+	}
+	return operators
+}
+
+// operatorCapabilities returns the capabilities configured for the operator in
+// OperatorConfigs.
+func (c *Component) operatorCapabilities(operator string) []string {
+	var capabilities []string
+	for _, config := range c.OperatorConfigs {
+		if config.Name == operator {
+			capabilities = append(capabilities, config.Capabilities...)
+		}
+	}
+	return capabilities
+}
+
+// identifyOperator returns the operator a test is for and the capabilities derived from
+// the test by the built-in heuristics or OperatorTestPatterns.
+func (c *Component) identifyOperator(test *v1.TestInfo) (string, []string) {
+	for _, operator := range c.operators() {
 		// OpenShift tests related to operators (install, upgrade, etc)
 		if isOperatorTest, capabilities := util.IdentifyOperatorTest(operator, test.Name); isOperatorTest {
-			return true, capabilities
+			return operator, capabilities
 		}
 	}
 
-	return false, nil
+	// Custom patterns are consulted after the built-in heuristics, in operator name order.
+	for _, operator := range sets.List(sets.KeySet(c.OperatorTestPatterns)) {
+		for _, re := range c.operatorTestPatterns(operator) {
+			match := re.FindStringSubmatch(test.Name)
+			if match == nil {
+				continue
+			}
+			var capabilities []string
+			if i := re.SubexpIndex("Capability"); i >= 0 && match[i] != "" {
+				capabilities = []string{match[i]}
+			}
+			return operator, capabilities
+		}
+	}
+
+	return "", nil
 }
 
 func (c *Component) IdentifyVariants() ([]string, error) {
@@ -190,15 +1585,105 @@ func (c *Component) JiraProject() string {
 	return c.DefaultJiraProject
 }
 
-var namespaceShort = regexp.MustCompile(`ns/(?P<Namespace>[-\w]+)`)
-var namespaceFull = regexp.MustCompile(`namespace/(?P<Namespace>[-\w]+)`)
+// JiraProjectFor returns the Jira project of a test claimed with the matcher returned by
+// FindMatch: the matcher's JiraProject, or the component's DefaultJiraProject if it
+// doesn't override it.
+func (c *Component) JiraProjectFor(matcher *ComponentMatcher) string {
+	if matcher != nil && matcher.JiraProject != "" {
+		return matcher.JiraProject
+	}
+	return c.JiraProject()
+}
 
-func ExtractNamespaceFromTestName(in string) string {
-	if namespaceShort.MatchString(in) {
-		return namespaceShort.FindStringSubmatch(in)[1]
+var namespaceShort = regexp.MustCompile(`(?i:ns)/(?P<Namespace>[-\w]+)`)
+var namespaceFull = regexp.MustCompile(`(?i:namespace)/(?P<Namespace>[-\w]+)`)
+
+var (
+	// namespacePatterns are the default patterns used to find the namespaces a test
+	// references, see RegisterNamespacePattern. The slice is replaced rather than modified,
+	// so it's safe to use once read under namespacePatternsLock.
+	namespacePatterns     = []*regexp.Regexp{namespaceShort, namespaceFull}
+	namespacePatternsLock sync.RWMutex
+)
+
+// RegisterNamespacePattern adds a regular expression to the default patterns used to find
+// the namespaces tests reference, for every component. The pattern must have a named
+// Namespace capture group, e.g. `in namespace "(?P<Namespace>[-\w]+)"`. It's safe to call
+// while tests are being matched.
+func RegisterNamespacePattern(pattern string) error {
+	re, err := compileNamespacePattern(pattern)
+	if err != nil {
+		return err
 	}
-	if namespaceFull.MatchString(in) {
-		return namespaceFull.FindStringSubmatch(in)[1]
+
+	namespacePatternsLock.Lock()
+	defer namespacePatternsLock.Unlock()
+	namespacePatterns = append(append([]*regexp.Regexp{}, namespacePatterns...), re)
+	invalidateMatchCaches()
+	return nil
+}
+
+// ResetNamespacePatterns removes every pattern added by RegisterNamespacePattern.
+func ResetNamespacePatterns() {
+	namespacePatternsLock.Lock()
+	defer namespacePatternsLock.Unlock()
+	namespacePatterns = []*regexp.Regexp{namespaceShort, namespaceFull}
+	invalidateMatchCaches()
+}
+
+// defaultNamespacePatterns returns the current default namespace patterns. The result must
+// not be modified.
+func defaultNamespacePatterns() []*regexp.Regexp {
+	namespacePatternsLock.RLock()
+	defer namespacePatternsLock.RUnlock()
+	return namespacePatterns
+}
+
+// ExtractNamespaceFromTestName returns the first namespace referenced in the test name.
+func ExtractNamespaceFromTestName(in string) string {
+	if namespaces := ExtractNamespacesFromTestName(in); len(namespaces) > 0 {
+		return namespaces[0]
 	}
 	return ""
 }
+
+// ExtractNamespacesFromTestName returns every namespace referenced in the test name, in the
+// order they appear, without duplicates. The ns/ and namespace/ prefixes are matched in any
+// case, e.g. NS/ or Namespace/, but namespaces are returned as written.
+func ExtractNamespacesFromTestName(in string) []string {
+	return extractNamespaces(in, defaultNamespacePatterns())
+}
+
+// extractNamespaces returns the Namespace capture group of every match of the patterns in
+// the test name, in the order they appear, without duplicates.
+func extractNamespaces(in string, patterns []*regexp.Regexp) []string {
+	type occurrence struct {
+		position  int
+		namespace string
+	}
+
+	var occurrences []occurrence
+	for _, re := range patterns {
+		group := re.SubexpIndex("Namespace")
+		for _, match := range re.FindAllStringSubmatchIndex(in, -1) {
+			start, end := match[2*group], match[2*group+1]
+			if start < 0 || start == end {
+				continue
+			}
+			occurrences = append(occurrences, occurrence{position: start, namespace: in[start:end]})
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].position < occurrences[j].position
+	})
+
+	var namespaces []string
+	seen := sets.New[string]()
+	for _, o := range occurrences {
+		if !seen.Has(o.namespace) {
+			seen.Insert(o.namespace)
+			namespaces = append(namespaces, o.namespace)
+		}
+	}
+	return namespaces
+}
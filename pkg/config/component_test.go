@@ -1,12 +1,19 @@
 package config
 
 import (
+	"errors"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
 )
 
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 func TestComponent_FindMatch(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -107,31 +114,2467 @@ func TestComponent_FindMatch(t *testing.T) {
 			},
 			matches: true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			c := &Component{
-				Matchers: []ComponentMatcher{tt.matcher},
-			}
-			got := c.FindMatch(&tt.test)
-			if !tt.matches && got != nil {
-				t.Errorf("FindMatch() matched, but we didn't want one")
-			}
-
-			if tt.matches && (got == nil || !reflect.DeepEqual(*got, tt.matcher)) {
-				t.Errorf("FindMatch() did not match, but we wanted a match")
-			}
-		})
-	}
-}
-
-func TestIHateRegexes(t *testing.T) {
-	actual := ExtractNamespaceFromTestName("[sig-arch][bz-Unknown][Late] Alerts [apigroup:monitoring.coreos.com] alert/KubePodNotReady should not be at or above info in ns/openshift [Suite:openshift/conformance/parallel]")
-	if actual != "openshift" {
-		t.Fatal(actual)
-	}
-	actual = ExtractNamespaceFromTestName("[sig-arch][bz-openshift-apiserver][Late] Alerts [apigroup:monitoring.coreos.com] alert/KubePodNotReady should not be at or above info in ns/openshift-apiserver [Suite:openshift/conformance/parallel]")
-	if actual != "openshift-apiserver" {
-		t.Fatal(actual)
+		{
+			name: "exclude suite rejects test in suite",
+			matcher: ComponentMatcher{
+				IncludeAll:   []string{"Unidling"},
+				ExcludeSuite: []string{"openshift-tests-upgrade", "openshift-tests"},
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+				Suite: "openshift-tests",
+			},
+			matches: false,
+		},
+		{
+			name: "exclude suite matches test in other suite",
+			matcher: ComponentMatcher{
+				IncludeAll:   []string{"Unidling"},
+				ExcludeSuite: []string{"openshift-tests-upgrade"},
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+				Suite: "openshift-tests",
+			},
+			matches: true,
+		},
+		{
+			name: "no sig matches untagged test in suite",
+			matcher: ComponentMatcher{
+				NoSIG:      true,
+				Suite:      "openshift-tests",
+				IncludeAll: []string{"alert/"},
+			},
+			test: v1.TestInfo{
+				Name:  "[bz-etcd][invariant] alert/etcdMembersDown should not be at or above info",
+				Suite: "openshift-tests",
+			},
+			matches: true,
+		},
+		{
+			name: "no sig doesn't match tagged test",
+			matcher: ComponentMatcher{
+				NoSIG:      true,
+				Suite:      "openshift-tests",
+				IncludeAll: []string{"alert/"},
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-arch] alert/KubePodNotReady should not be at or above info in ns/openshift-console",
+				Suite: "openshift-tests",
+			},
+			matches: false,
+		},
+		{
+			name: "no sig is ANDed with suite",
+			matcher: ComponentMatcher{
+				NoSIG: true,
+				Suite: "openshift-tests",
+			},
+			test: v1.TestInfo{
+				Name:  "[bz-etcd][invariant] alert/etcdMembersDown should not be at or above info",
+				Suite: "openshift-tests-upgrade",
+			},
+			matches: false,
+		},
+		{
+			name: "is disruption matches disruption test",
+			matcher: ComponentMatcher{
+				IsDisruption: true,
+				IncludeAll:   []string{"ns/openshift-console"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge] disruption/ingress-to-console connection/new ns/openshift-console should be available throughout the test",
+			},
+			matches: true,
+		},
+		{
+			name: "is disruption doesn't match other test",
+			matcher: ComponentMatcher{
+				IsDisruption: true,
+				IncludeAll:   []string{"ns/openshift-console"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-arch] alert/KubePodNotReady should not be at or above info in ns/openshift-console",
+			},
+			matches: false,
+		},
+		{
+			name: "include word matches whole word",
+			matcher: ComponentMatcher{
+				IncludeWord: []string{"TCP", "Unidling"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "include word doesn't match inside a word",
+			matcher: ComponentMatcher{
+				IncludeWord: []string{"Idling"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge] Unidling [apigroup:apps.openshift.io] should handle many TCP connections",
+			},
+			matches: false,
+		},
+		{
+			name: "include word case-insensitive",
+			matcher: ComponentMatcher{
+				IncludeWord:     []string{"i/tcp", "[serial]"},
+				CaseInsensitive: true,
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "exclude word rejects whole word",
+			matcher: ComponentMatcher{
+				IncludeAll:  []string{"Unidling"},
+				ExcludeWord: []string{"UDP", "TCP"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "exclude word ignores part of a word",
+			matcher: ComponentMatcher{
+				IncludeAll:  []string{"Unidling"},
+				ExcludeWord: []string{"Idling", "connection"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge] Unidling [apigroup:apps.openshift.io] should handle many TCP connections",
+			},
+			matches: true,
+		},
+		{
+			name: "number meets threshold",
+			matcher: ComponentMatcher{
+				Number: &NumberCondition{Pattern: `scale to (?P<Number>\d+) pods`, Threshold: ">= 50"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-scalability] deployments should scale to 100 pods",
+			},
+			matches: true,
+		},
+		{
+			name: "number below threshold",
+			matcher: ComponentMatcher{
+				Number: &NumberCondition{Pattern: `scale to (?P<Number>\d+) pods`, Threshold: ">=50"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-scalability] deployments should scale to 10 pods",
+			},
+			matches: false,
+		},
+		{
+			name: "number capture isn't numeric",
+			matcher: ComponentMatcher{
+				Number: &NumberCondition{Pattern: `scale to (?P<Number>\w+) pods`, Threshold: "> 0"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-scalability] deployments should scale to many pods",
+			},
+			matches: false,
+		},
+		{
+			name: "number pattern doesn't match",
+			matcher: ComponentMatcher{
+				Number: &NumberCondition{Pattern: `scale to (?P<Number>\d+) pods`, Threshold: "> 0"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-scalability] deployments should scale down",
+			},
+			matches: false,
+		},
+		{
+			name: "number capture is missing",
+			matcher: ComponentMatcher{
+				Number: &NumberCondition{Pattern: `scale (up|to (?P<Number>\d+) pods)`, Threshold: "!= 0"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-scalability] deployments should scale up",
+			},
+			matches: false,
+		},
+		{
+			name: "name matches regex matches the whole name",
+			matcher: ComponentMatcher{
+				NameMatchesRegex: `[a-z0-9]{32}`,
+			},
+			test: v1.TestInfo{
+				Name: "3f2a6c0e9b1d4e7f8a5c2b0d6e9f1a3c",
+			},
+			matches: true,
+		},
+		{
+			name: "name matches regex is anchored",
+			matcher: ComponentMatcher{
+				NameMatchesRegex: `[a-z0-9]{32}|\S+`,
+			},
+			test: v1.TestInfo{
+				Name: "[sig-storage] 3f2a6c0e9b1d4e7f8a5c2b0d6e9f1a3c should mount",
+			},
+			matches: false,
+		},
+		{
+			name: "include all alternation matches either alternative",
+			matcher: ComponentMatcher{
+				IncludeAll: []string{"SCTP|TCP", "Unidling", "connections"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "include all alternation still requires the plain entries",
+			matcher: ComponentMatcher{
+				IncludeAll: []string{"SCTP|TCP", "Unidling", "datagrams"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "include all alternation requires one of the alternatives",
+			matcher: ComponentMatcher{
+				IncludeAll: []string{"SCTP|UDP", "Unidling"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "include all alternation with case-insensitive alternative",
+			matcher: ComponentMatcher{
+				IncludeAll: []string{"SCTP|i/tcp", "Unidling"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "match clean name matches across tags",
+			matcher: ComponentMatcher{
+				MatchCleanName: true,
+				IncludeAll:     []string{"Unidling should handle many TCP connections"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "raw name doesn't match across tags",
+			matcher: ComponentMatcher{
+				IncludeAll: []string{"Unidling should handle many TCP connections"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "match clean name doesn't match tags",
+			matcher: ComponentMatcher{
+				MatchCleanName: true,
+				IncludeAny:     []string{"Feature:Idling", "sig-network-edge"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "params match",
+			matcher: ComponentMatcher{
+				Params: map[string]string{"when": "upgrade"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-cli][when=upgrade] oc adm upgrade should work [Feature:when=install]",
+			},
+			matches: true,
+		},
+		{
+			name: "params require every value",
+			matcher: ComponentMatcher{
+				Params: map[string]string{"when": "upgrade", "arch": "arm64"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-cli][when=upgrade][arch=amd64] oc adm upgrade should work",
+			},
+			matches: false,
+		},
+		{
+			name: "params are not jira style fields",
+			matcher: ComponentMatcher{
+				Params: map[string]string{"when": "upgrade"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-cli][when:upgrade] oc adm upgrade should work",
+			},
+			matches: false,
+		},
+		{
+			name: "suite include all matches a substring only in the suite",
+			matcher: ComponentMatcher{
+				SuiteIncludeAll: []string{"conformance", "parallel"},
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network] pods should be ready",
+				Suite: "openshift/conformance/parallel",
+			},
+			matches: true,
+		},
+		{
+			name: "suite include all ignores the name",
+			matcher: ComponentMatcher{
+				SuiteIncludeAll: []string{"conformance"},
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network] pods should be ready [Suite:openshift/conformance/parallel]",
+				Suite: "openshift-tests",
+			},
+			matches: false,
+		},
+		{
+			name: "suite include any matches case-insensitively",
+			matcher: ComponentMatcher{
+				SuiteIncludeAny: []string{"i/SERIAL", "upgrade"},
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network] pods should be ready",
+				Suite: "openshift/conformance/serial",
+			},
+			matches: true,
+		},
+		{
+			name: "suite include any requires a substring",
+			matcher: ComponentMatcher{
+				SuiteIncludeAny: []string{"serial", "upgrade"},
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network] serial pods should be ready",
+				Suite: "openshift/conformance/parallel",
+			},
+			matches: false,
+		},
+		{
+			name: "path glob matches a nested path",
+			matcher: ComponentMatcher{
+				PathGlob: []string{"test/e2e/network/**", "test/e2e/storage/**"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-storage] CSI snapshots should be restored",
+				Path: "test/e2e/storage/csi/snapshot.go",
+			},
+			matches: true,
+		},
+		{
+			name: "path glob star stays within a segment",
+			matcher: ComponentMatcher{
+				PathGlob: []string{"test/e2e/storage/*.go"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-storage] CSI snapshots should be restored",
+				Path: "test/e2e/storage/csi/snapshot.go",
+			},
+			matches: false,
+		},
+		{
+			name: "path glob never matches a test without a path",
+			matcher: ComponentMatcher{
+				PathGlob: []string{"**"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-storage] CSI snapshots should be restored",
+			},
+			matches: false,
+		},
+		{
+			name: "serial matches a test serial by suite",
+			matcher: ComponentMatcher{
+				SIG:    "sig-network",
+				Serial: boolPtr(true),
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network] pods should be ready",
+				Suite: "openshift/conformance/serial",
+			},
+			matches: true,
+		},
+		{
+			name: "serial matches a test serial by tag",
+			matcher: ComponentMatcher{
+				SIG:    "sig-network",
+				Serial: boolPtr(true),
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network] pods should be ready [Serial]",
+				Suite: "openshift-tests",
+			},
+			matches: true,
+		},
+		{
+			name: "serial requires a serial test",
+			matcher: ComponentMatcher{
+				SIG:    "sig-network",
+				Serial: boolPtr(true),
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network] pods should be ready",
+				Suite: "openshift/conformance/parallel",
+			},
+			matches: false,
+		},
+		{
+			name: "serial false requires a parallel test",
+			matcher: ComponentMatcher{
+				SIG:    "sig-network",
+				Serial: boolPtr(false),
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network] pods should be ready [Serial]",
+				Suite: "openshift/conformance/parallel",
+			},
+			matches: false,
+		},
+		{
+			name: "expr matches either branch",
+			matcher: ComponentMatcher{
+				Expr: `(contains("ovn") AND sig("sig-network")) OR (suite("openshift-tests") AND NOT contains("sdn"))`,
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-storage] volumes should mount",
+				Suite: "openshift-tests",
+			},
+			matches: true,
+		},
+		{
+			name: "expr not excludes the test",
+			matcher: ComponentMatcher{
+				Expr: `(contains("ovn") AND sig("sig-network")) OR (suite("openshift-tests") AND NOT contains("sdn"))`,
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-storage] sdn volumes should mount",
+				Suite: "openshift-tests",
+			},
+			matches: false,
+		},
+		{
+			name: "annotations match an annotated test",
+			matcher: ComponentMatcher{
+				SIG:         "sig-network",
+				Annotations: []string{AnnotationLongName},
+			},
+			test: v1.TestInfo{
+				Name:        "[sig-network] pods should be ready",
+				Annotations: []string{AnnotationLongName},
+			},
+			matches: true,
+		},
+		{
+			name: "annotations require the annotation",
+			matcher: ComponentMatcher{
+				SIG:         "sig-network",
+				Annotations: []string{AnnotationLongName},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network] pods should be ready",
+			},
+			matches: false,
+		},
+		{
+			name: "negate claims tests the conditions reject",
+			matcher: ComponentMatcher{
+				SIG:        "sig-network",
+				ExcludeAny: []string{"sdn"},
+				Negate:     true,
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network] sdn pods should be ready",
+			},
+			matches: true,
+		},
+		{
+			name: "negate rejects tests the conditions hold for",
+			matcher: ComponentMatcher{
+				SIG:        "sig-network",
+				ExcludeAny: []string{"sdn"},
+				Negate:     true,
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network] ovn pods should be ready",
+			},
+			matches: false,
+		},
+		{
+			name: "negate without conditions never matches",
+			matcher: ComponentMatcher{
+				Negate: true,
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network] ovn pods should be ready",
+			},
+			matches: false,
+		},
+		{
+			name: "include regex matches",
+			matcher: ComponentMatcher{
+				IncludeRegex: []string{`Unidling .* should handle many (TCP|UDP) connections`},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "include regex requires all to match",
+			matcher: ComponentMatcher{
+				IncludeRegex: []string{`Unidling`, `should handle many (SCTP|UDP) connections`},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "include none matches no value",
+			matcher: ComponentMatcher{
+				IncludeNone: []string{"Ingress", "Routing"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "include none rejects any value, unlike exclude all",
+			matcher: ComponentMatcher{
+				IncludeNone: []string{"Unidling", "Ingress"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "exclude all doesn't reject only some values",
+			matcher: ComponentMatcher{
+				ExcludeAll: []string{"Unidling", "Ingress"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "include none is ANDed with the other conditions",
+			matcher: ComponentMatcher{
+				SIG:         "sig-network",
+				IncludeNone: []string{"Ingress"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "include none case-insensitive rejects mixed case",
+			matcher: ComponentMatcher{
+				IncludeNone: []string{"i/UNIDLING"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "include any is case-sensitive by default",
+			matcher: ComponentMatcher{
+				IncludeAny: []string{"unidling"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "include any case-insensitive matches mixed case",
+			matcher: ComponentMatcher{
+				IncludeAny:      []string{"unidling"},
+				CaseInsensitive: true,
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "include all case-insensitive matches mixed case",
+			matcher: ComponentMatcher{
+				IncludeAll:      []string{"UNIDLING", "Tcp Connections"},
+				CaseInsensitive: true,
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "include all case-insensitive does not match missing substring",
+			matcher: ComponentMatcher{
+				IncludeAll:      []string{"unidling", "udp connections"},
+				CaseInsensitive: true,
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "exclude any case-insensitive excludes mixed case",
+			matcher: ComponentMatcher{
+				SIG:             "sig-network-edge",
+				ExcludeAny:      []string{"IDLING"},
+				CaseInsensitive: true,
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "variants match when all are present",
+			matcher: ComponentMatcher{
+				SIG:      "sig-network-edge",
+				Variants: []string{"platform:aws", "network:ovn"},
+			},
+			test: v1.TestInfo{
+				Name:     "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+				Variants: []string{"arch:amd64", "network:ovn", "platform:aws"},
+			},
+			matches: true,
+		},
+		{
+			name: "variants do not match when one is missing",
+			matcher: ComponentMatcher{
+				SIG:      "sig-network-edge",
+				Variants: []string{"platform:aws", "network:sdn"},
+			},
+			test: v1.TestInfo{
+				Name:     "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+				Variants: []string{"arch:amd64", "network:ovn", "platform:aws"},
+			},
+			matches: false,
+		},
+		{
+			name: "variants do not match test without variants",
+			matcher: ComponentMatcher{
+				Variants: []string{"platform:aws"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "sig any matches one of the sigs",
+			matcher: ComponentMatcher{
+				SIGAny: []string{"sig-auth", "sig-network-edge"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "sig any does not match other sigs",
+			matcher: ComponentMatcher{
+				SIGAny: []string{"sig-auth", "sig-storage"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "sig and sig any both required",
+			matcher: ComponentMatcher{
+				SIG:    "sig-network-edge",
+				SIGAny: []string{"sig-auth", "sig-storage"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "sig and sig any both match",
+			matcher: ComponentMatcher{
+				SIG:    "sig-network-edge",
+				SIGAny: []string{"sig-auth", "sig-network"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][sig-network] Unidling should handle many TCP connections",
+			},
+			matches: true,
+		},
+		{
+			name: "include glob matches",
+			matcher: ComponentMatcher{
+				IncludeGlob: []string{"*Unidling*TCP connections*"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "include glob is anchored to the whole name",
+			matcher: ComponentMatcher{
+				IncludeGlob: []string{"Unidling*"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "include glob and include all are ANDed",
+			matcher: ComponentMatcher{
+				IncludeAll:  []string{"Ingress"},
+				IncludeGlob: []string{"*Unidling*"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "exclude glob forces a non-match",
+			matcher: ComponentMatcher{
+				SIG:         "sig-network-edge",
+				ExcludeGlob: []string{"*Ingress*", "*[[]Serial]*"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "invalid glob never matches",
+			matcher: ComponentMatcher{
+				SIG:         "sig-network-edge",
+				IncludeGlob: []string{"*[Unidling"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: false,
+		},
+		{
+			name: "suite any matches one of the suites",
+			matcher: ComponentMatcher{
+				SuiteAny: []string{"openshift/conformance/parallel", "openshift/conformance/serial"},
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+				Suite: "openshift/conformance/serial",
+			},
+			matches: true,
+		},
+		{
+			name: "suite any does not match other suites",
+			matcher: ComponentMatcher{
+				SuiteAny: []string{"openshift/conformance/parallel", "openshift/conformance/serial"},
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+				Suite: "openshift/disruptive",
+			},
+			matches: false,
+		},
+		{
+			name: "suite prefix matches suites under the prefix",
+			matcher: ComponentMatcher{
+				SuitePrefix: "openshift/conformance/",
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+				Suite: "openshift/conformance/serial",
+			},
+			matches: true,
+		},
+		{
+			name: "suite prefix does not match other suites",
+			matcher: ComponentMatcher{
+				SuitePrefix: "openshift/conformance/",
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+				Suite: "openshift/disruptive",
+			},
+			matches: false,
+		},
+		{
+			name: "suite and suite any are both required",
+			matcher: ComponentMatcher{
+				Suite:    "openshift/conformance/parallel",
+				SuiteAny: []string{"openshift/conformance/parallel", "openshift/conformance/serial"},
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+				Suite: "openshift/conformance/serial",
+			},
+			matches: false,
+		},
+		{
+			name: "suite matcher skips excluded sig",
+			matcher: ComponentMatcher{
+				Suite:      "openshift/conformance/parallel",
+				ExcludeSIG: []string{"sig-storage", "sig-network-edge"},
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+				Suite: "openshift/conformance/parallel",
+			},
+			matches: false,
+		},
+		{
+			name: "suite matcher matches sig that is not excluded",
+			matcher: ComponentMatcher{
+				Suite:      "openshift/conformance/parallel",
+				ExcludeSIG: []string{"sig-storage"},
+			},
+			test: v1.TestInfo{
+				Name:  "[sig-network-edge][Feature:Idling] Unidling [apigroup:apps.openshift.io][apigroup:route.openshift.io] should handle many TCP connections by possibly dropping those over a certain bound [Serial] [Skipped:Network/OVNKubernetes]",
+				Suite: "openshift/conformance/parallel",
+			},
+			matches: true,
+		},
+		{
+			name: "name prefix matches",
+			matcher: ComponentMatcher{
+				NamePrefix: "[sig-network]",
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network] Services should serve endpoints on same port and different protocols [Suite:openshift/conformance/parallel]",
+			},
+			matches: true,
+		},
+		{
+			name: "name prefix doesn't match mid-string occurrence",
+			matcher: ComponentMatcher{
+				NamePrefix: "[sig-network]",
+			},
+			test: v1.TestInfo{
+				Name: "[sig-arch][Late] tests should not fail [sig-network] cleanup [Suite:openshift/conformance/parallel]",
+			},
+			matches: false,
+		},
+		{
+			name: "name suffix matches",
+			matcher: ComponentMatcher{
+				NameSuffix: "[Serial]",
+			},
+			test: v1.TestInfo{
+				Name: "[sig-etcd] etcd leader changes are not excessive [Serial]",
+			},
+			matches: true,
+		},
+		{
+			name: "name prefix and suffix are ANDed",
+			matcher: ComponentMatcher{
+				NamePrefix: "[sig-etcd]",
+				NameSuffix: "[Serial]",
+			},
+			test: v1.TestInfo{
+				Name: "[sig-etcd] etcd leader changes are not excessive [Late]",
+			},
+			matches: false,
+		},
+		{
+			name: "tags match",
+			matcher: ComponentMatcher{
+				Tags: map[string][]string{
+					"Feature": {"Idling"},
+					"Serial":  nil,
+				},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling should handle many TCP connections [Serial] [Skipped:Network/OVNKubernetes]",
+			},
+			matches: true,
+		},
+		{
+			name: "tags require every value",
+			matcher: ComponentMatcher{
+				Tags: map[string][]string{
+					"Feature": {"Idling", "Router"},
+				},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling should handle many TCP connections [Serial]",
+			},
+			matches: false,
+		},
+		{
+			name: "tags require flag",
+			matcher: ComponentMatcher{
+				Tags: map[string][]string{
+					"Disruptive": nil,
+				},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling should handle many TCP connections [Serial]",
+			},
+			matches: false,
+		},
+		{
+			name: "mixed case-insensitive and exact include any entries",
+			matcher: ComponentMatcher{
+				IncludeAny: []string{"i/MUST-GATHER", "OVN"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-cli] oc adm must-gather runs successfully",
+			},
+			matches: true,
+		},
+		{
+			name: "exact include any entry stays case-sensitive",
+			matcher: ComponentMatcher{
+				IncludeAny: []string{"i/MUST-GATHER", "OVN"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network] ovn pods should be ready",
+			},
+			matches: false,
+		},
+		{
+			name: "mixed case-insensitive and exact include all entries",
+			matcher: ComponentMatcher{
+				IncludeAll: []string{"i/sig-NETWORK", "OVN"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network] OVN pods should be ready",
+			},
+			matches: true,
+		},
+		{
+			name: "case-insensitive exclude entry",
+			matcher: ComponentMatcher{
+				IncludeAll: []string{"OVN"},
+				ExcludeAny: []string{"i/SERIAL"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network] OVN pods should be ready [Serial]",
+			},
+			matches: false,
+		},
+		{
+			name: "repo matches",
+			matcher: ComponentMatcher{
+				SIG:  "sig-network",
+				Repo: "openshift/origin",
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network] pods should have networking",
+				Repo: "openshift/origin",
+			},
+			matches: true,
+		},
+		{
+			name: "repo doesn't match a component's own e2e suite",
+			matcher: ComponentMatcher{
+				SIG:  "sig-network",
+				Repo: "openshift/origin",
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network] pods should have networking",
+				Repo: "openshift/ovn-kubernetes",
+			},
+			matches: false,
+		},
+		{
+			name: "repo doesn't match unknown repo",
+			matcher: ComponentMatcher{
+				Repo: "openshift/origin",
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network] pods should have networking",
+			},
+			matches: false,
+		},
+		{
+			name: "repo any matches",
+			matcher: ComponentMatcher{
+				RepoAny: []string{"openshift/ovn-kubernetes", "openshift/sdn"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-network] pods should have networking",
+				Repo: "openshift/sdn",
+			},
+			matches: true,
+		},
+		{
+			name: "required flags match regardless of sig",
+			matcher: ComponentMatcher{
+				RequireFlags: []string{"Disruptive", "Serial"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-etcd][Feature:DisasterRecovery][Disruptive] etcd should recover from quorum loss [Serial]",
+			},
+			matches: true,
+		},
+		{
+			name: "required flags must all be present",
+			matcher: ComponentMatcher{
+				RequireFlags: []string{"Disruptive", "Serial"},
+			},
+			test: v1.TestInfo{
+				Name: "[sig-etcd][Feature:DisasterRecovery][Disruptive] etcd should recover from quorum loss",
+			},
+			matches: false,
+		},
+		{
+			name: "top level contains matches the first segment",
+			matcher: ComponentMatcher{
+				TopLevelContains: []string{"TestUpgrade"},
+			},
+			test: v1.TestInfo{
+				Name: "TestUpgrade/control-plane/etcd should be healthy",
+			},
+			matches: true,
+		},
+		{
+			name: "top level contains ignores subtest names",
+			matcher: ComponentMatcher{
+				TopLevelContains: []string{"etcd"},
+			},
+			test: v1.TestInfo{
+				Name: "TestUpgrade/control-plane/etcd should be healthy",
+			},
+			matches: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Component{
+				Matchers: []ComponentMatcher{tt.matcher},
+			}
+			got := c.FindMatch(&tt.test)
+			if !tt.matches && got != nil {
+				t.Errorf("FindMatch() matched, but we didn't want one")
+			}
+
+			if tt.matches && (got == nil || !reflect.DeepEqual(*got, tt.matcher)) {
+				t.Errorf("FindMatch() did not match, but we wanted a match")
+			}
+
+			// ExplainNonMatch must agree, and name a failed condition for every non-match.
+			failures := ExplainNonMatch(c, &tt.test)
+			if tt.matches && len(failures) > 0 {
+				t.Errorf("ExplainNonMatch() = %+v for a match", failures)
+			}
+			if !tt.matches && (len(failures) != 1 || len(failures[0].Conditions) == 0) {
+				t.Errorf("ExplainNonMatch() = %+v, want a failed condition", failures)
+			}
+		})
+	}
+}
+
+func TestComponent_FindMatchNegate(t *testing.T) {
+	component := &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking",
+		Matchers: []ComponentMatcher{
+			{SIG: "sig-network", IncludeAll: []string{"ovn"}, JiraComponent: "Networking / ovn-kubernetes"},
+			{SIG: "sig-network", Negate: true, Priority: PriorityFallback, JiraComponent: "Networking / triage"},
+		},
+	}
+	storage := &Component{
+		Name:                 "Storage",
+		DefaultJiraComponent: "Storage",
+		Matchers:             []ComponentMatcher{{SIG: "sig-storage"}},
+	}
+
+	tests := []struct {
+		name      string
+		test      string
+		wantOwner string
+		wantJira  string
+	}{
+		{
+			name:      "positive matcher claims first",
+			test:      "[sig-network] ovn pods should be ready",
+			wantOwner: "Networking",
+			wantJira:  "Networking / ovn-kubernetes",
+		},
+		{
+			name:      "negated matcher defers to other components",
+			test:      "[sig-storage] volumes should mount",
+			wantOwner: "Storage",
+			wantJira:  "Storage",
+		},
+		{
+			name:      "negated matcher claims what nothing else does",
+			test:      "[sig-arch] should be owned",
+			wantOwner: "Networking",
+			wantJira:  "Networking / triage",
+		},
+		{
+			name: "negated matcher rejects tests its conditions hold for",
+			test: "[sig-network] sdn pods should be ready",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, matcher := Resolve([]*Component{component, storage}, &v1.TestInfo{Name: tt.test})
+			if tt.wantOwner == "" {
+				if owner != nil {
+					t.Errorf("Resolve() = %s, want no owner", owner.Name)
+				}
+				return
+			}
+			if owner == nil || owner.Name != tt.wantOwner {
+				t.Fatalf("Resolve() = %v, want %s", owner, tt.wantOwner)
+			}
+			if got := owner.jiraComponentFor(matcher); got != tt.wantJira {
+				t.Errorf("Resolve() jira component = %q, want %q", got, tt.wantJira)
+			}
+		})
+	}
+
+	_, reason := component.FindMatchWithReason(&v1.TestInfo{Name: "[sig-arch] should be owned"})
+	if reason.MatcherIndex != 1 || reason.Confidence != ConfidenceLow || reason.SIGs != nil {
+		t.Errorf("FindMatchWithReason() reason = %+v, want a low confidence claim by matcher 1", reason)
+	}
+}
+
+func TestComponent_Compile(t *testing.T) {
+	c := &Component{
+		Name: "Networking / router",
+		Matchers: []ComponentMatcher{
+			{IncludeGlob: []string{"*Unidling*"}},
+		},
+	}
+	if err := c.Compile(); err != nil {
+		t.Fatalf("Compile() returned unexpected error: %+v", err)
+	}
+	if got := c.FindMatch(&v1.TestInfo{Name: "[sig-network-edge] Unidling should work"}); got == nil {
+		t.Errorf("FindMatch() did not match compiled glob")
+	}
+
+	c.Matchers = append(c.Matchers, ComponentMatcher{ExcludeGlob: []string{"[abc"}})
+	if err := c.Compile(); err == nil {
+		t.Errorf("Compile() did not return an error for an invalid glob")
+	}
+}
+
+func TestComponent_FindMatchWithReason(t *testing.T) {
+	component := &Component{
+		DefaultJiraComponent: "Networking / router",
+		Operators:            []string{"ingress"},
+		Namespaces:           []string{"openshift-ingress"},
+		Matchers: []ComponentMatcher{
+			{
+				SIG: "sig-auth",
+			},
+			{
+				SIG:        "sig-network-edge",
+				IncludeAll: []string{"Unidling"},
+				IncludeAny: []string{"TCP", "UDP"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		test       v1.TestInfo
+		wantMatch  bool
+		wantReason MatchReason
+	}{
+		{
+			name: "jira field",
+			test: v1.TestInfo{
+				Name: `[Jira:"Networking / router"] should do a thing`,
+			},
+			wantMatch: true,
+			wantReason: MatchReason{
+				Source:        MatchSourceJiraField,
+				JiraComponent: "Networking / router",
+				Confidence:    ConfidenceHigh,
+			},
+		},
+		{
+			name: "operator",
+			test: v1.TestInfo{
+				Name: "Cluster upgrade.Operator upgrade ingress",
+			},
+			wantMatch: true,
+			wantReason: MatchReason{
+				Source:     MatchSourceOperator,
+				Operator:   "ingress",
+				Confidence: ConfidenceHigh,
+			},
+		},
+		{
+			name: "matcher",
+			test: v1.TestInfo{
+				Name: "[sig-network-edge][Feature:Idling] Unidling should handle many TCP connections",
+			},
+			wantMatch: true,
+			wantReason: MatchReason{
+				Source:       MatchSourceMatcher,
+				MatcherIndex: 1,
+				SIGs:         []string{"sig-network-edge"},
+				Substrings:   []string{"Unidling", "TCP"},
+				Confidence:   ConfidenceHigh,
+			},
+		},
+		{
+			name: "broad matcher",
+			test: v1.TestInfo{
+				Name: "[sig-auth] should authenticate",
+			},
+			wantMatch: true,
+			wantReason: MatchReason{
+				Source:       MatchSourceMatcher,
+				MatcherIndex: 0,
+				SIGs:         []string{"sig-auth"},
+				Confidence:   ConfidenceLow,
+			},
+		},
+		{
+			name: "namespace",
+			test: v1.TestInfo{
+				Name: "[sig-arch] alert/KubePodNotReady should not be at or above info in ns/openshift-ingress",
+			},
+			wantMatch: true,
+			wantReason: MatchReason{
+				Source:     MatchSourceNamespace,
+				Namespace:  "openshift-ingress",
+				Confidence: ConfidenceMedium,
+			},
+		},
+		{
+			name: "no match",
+			test: v1.TestInfo{
+				Name: "[sig-storage] some storage test",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := component.FindMatchWithReason(&tt.test)
+			if tt.wantMatch != (got != nil) {
+				t.Fatalf("FindMatchWithReason() matched = %v, want %v", got != nil, tt.wantMatch)
+			}
+			if !reflect.DeepEqual(reason, tt.wantReason) {
+				t.Errorf("FindMatchWithReason() reason = %+v, want %+v", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestComponent_FindMatchJiraField(t *testing.T) {
+	tests := []struct {
+		name          string
+		jiraComponent string
+		test          string
+	}{
+		{
+			name:          "quoted bracket field",
+			jiraComponent: "OLM / Dependency Resolution",
+			test:          `[Jira:"OLM / Dependency Resolution"] should resolve dependencies`,
+		},
+		{
+			name:          "quoted slash field",
+			jiraComponent: "OLM / Dependency Resolution",
+			test:          `jira/"OLM / Dependency Resolution" should resolve dependencies`,
+		},
+		{
+			name:          "single quoted field",
+			jiraComponent: "OLM / Dependency Resolution",
+			test:          `[Jira:'OLM / Dependency Resolution'] should resolve dependencies`,
+		},
+		{
+			name:          "quoted field with invalid escape",
+			jiraComponent: `Storage \ Operators`,
+			test:          `[Jira:"Storage \ Operators"] should mount`,
+		},
+		{
+			name:          "unquoted field",
+			jiraComponent: "Etcd",
+			test:          `[Jira:Etcd] should be healthy`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Component{DefaultJiraComponent: tt.jiraComponent}
+			m, reason := c.FindMatchWithReason(&v1.TestInfo{Name: tt.test})
+			if m == nil {
+				t.Fatalf("FindMatchWithReason() did not match")
+			}
+			if reason.Source != MatchSourceJiraField || reason.JiraComponent != tt.jiraComponent {
+				t.Errorf("FindMatchWithReason() reason = %+v, want jira field %q", reason, tt.jiraComponent)
+			}
+		})
+	}
+}
+
+func TestComponent_FindMatchExcludeJiraComponents(t *testing.T) {
+	networking := &Component{
+		Name:                  "Networking",
+		DefaultJiraComponent:  "Networking",
+		ExcludeJiraComponents: []string{"Networking / router"},
+		Matchers:              []ComponentMatcher{{IncludeAll: []string{"ovn"}}},
+	}
+	router := &Component{
+		Name:                 "Router",
+		DefaultJiraComponent: "Networking / router",
+		Matchers:             []ComponentMatcher{{IncludeAll: []string{"ingress"}}},
+	}
+
+	tests := []struct {
+		name       string
+		test       string
+		wantOwner  string
+		wantSource MatchSource
+	}{
+		{
+			name:       "jira field claimed",
+			test:       `[Jira:"Networking"] pods should be ready`,
+			wantOwner:  "Networking",
+			wantSource: MatchSourceJiraField,
+		},
+		{
+			name:       "re-homed jira field claimed by another component",
+			test:       `[Jira:"Networking"][Jira:"networking / router"] ingress should be ready`,
+			wantOwner:  "Router",
+			wantSource: MatchSourceJiraField,
+		},
+		{
+			name:       "re-homed jira field still claimed by a matcher",
+			test:       `[Jira:"Networking"][Jira:"Networking / router"] ovn pods should be ready`,
+			wantOwner:  "Networking",
+			wantSource: MatchSourceMatcher,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &v1.TestInfo{Name: tt.test}
+			owner, _ := Resolve([]*Component{networking, router}, test)
+			if owner == nil || owner.Name != tt.wantOwner {
+				t.Fatalf("Resolve() = %v, want %s", owner, tt.wantOwner)
+			}
+			if _, reason := owner.FindMatchWithReason(test); reason.Source != tt.wantSource {
+				t.Errorf("FindMatchWithReason() source = %s, want %s", reason.Source, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestComponent_FindMatchPriority(t *testing.T) {
+	component := &Component{
+		DefaultJiraComponent: "Etcd",
+		Operators:            []string{"etcd"},
+		Namespaces:           []string{"openshift-etcd"},
+		Matchers: []ComponentMatcher{
+			{IncludeAny: []string{"bz-etcd"}, Priority: 1},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		test         v1.TestInfo
+		wantPriority int
+	}{
+		{
+			name:         "jira field",
+			test:         v1.TestInfo{Name: "[Jira:Etcd] should be healthy"},
+			wantPriority: PriorityDefault,
+		},
+		{
+			name:         "operator",
+			test:         v1.TestInfo{Name: "operator install etcd"},
+			wantPriority: PriorityDefault,
+		},
+		{
+			name:         "matcher",
+			test:         v1.TestInfo{Name: "[bz-etcd] should be healthy"},
+			wantPriority: 1,
+		},
+		{
+			name:         "namespace",
+			test:         v1.TestInfo{Name: "alert/KubePodNotReady should not be at or above info in ns/openshift-etcd"},
+			wantPriority: PriorityNamespace,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := component.FindMatch(&tt.test)
+			if got == nil {
+				t.Fatalf("FindMatch() did not match")
+			}
+			if got.EffectivePriority() != tt.wantPriority {
+				t.Errorf("EffectivePriority() = %d, want %d", got.EffectivePriority(), tt.wantPriority)
+			}
+		})
+	}
+}
+
+func TestComponent_FindMatchDefaultCapabilities(t *testing.T) {
+	custom := &ComponentMatcher{JiraComponent: "Etcd", Capabilities: []string{"Custom"}}
+	component := &Component{
+		DefaultJiraComponent: "Etcd",
+		DefaultCapabilities:  []string{"Etcd", "Quorum"},
+		Operators:            []string{"etcd"},
+		Namespaces:           []string{"openshift-etcd"},
+		Matchers: []ComponentMatcher{
+			{IncludeAll: []string{"quorum"}, Capabilities: []string{"Quorum", "Raft"}},
+		},
+		CustomMatchers: []func(*v1.TestInfo) *ComponentMatcher{
+			func(test *v1.TestInfo) *ComponentMatcher {
+				if strings.Contains(test.Name, "custom") {
+					return custom
+				}
+				return nil
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		test v1.TestInfo
+		want []string
+	}{
+		{
+			name: "jira field",
+			test: v1.TestInfo{Name: "[Jira:Etcd] should be healthy"},
+			want: []string{"Etcd", "Quorum"},
+		},
+		{
+			name: "operator",
+			test: v1.TestInfo{Name: "operator install etcd"},
+			want: []string{"Etcd", "Quorum", "install"},
+		},
+		{
+			name: "matcher",
+			test: v1.TestInfo{Name: "etcd should keep quorum"},
+			want: []string{"Etcd", "Quorum", "Raft"},
+		},
+		{
+			name: "custom",
+			test: v1.TestInfo{Name: "etcd custom check"},
+			want: []string{"Custom", "Etcd", "Quorum"},
+		},
+		{
+			name: "namespace",
+			test: v1.TestInfo{Name: "alert/KubePodNotReady should not be at or above info in ns/openshift-etcd"},
+			want: []string{"Etcd", "Quorum"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := component.FindMatch(&tt.test)
+			if got == nil {
+				t.Fatalf("FindMatch() did not match")
+			}
+			if !reflect.DeepEqual(got.Capabilities, tt.want) {
+				t.Errorf("Capabilities = %q, want %q", got.Capabilities, tt.want)
+			}
+		})
+	}
+
+	if !reflect.DeepEqual(custom.Capabilities, []string{"Custom"}) {
+		t.Errorf("FindMatch() modified the custom matcher's capabilities to %q", custom.Capabilities)
+	}
+}
+
+func TestComponent_NamespacePriority(t *testing.T) {
+	etcd := &Component{
+		Name:                 "Etcd",
+		DefaultJiraComponent: "Etcd",
+		Namespaces:           []string{"openshift-etcd"},
+		NamespacePriority:    50,
+	}
+	router := &Component{
+		Name:                 "Router",
+		DefaultJiraComponent: "Router",
+		Matchers: []ComponentMatcher{
+			{IncludeAll: []string{"ns/openshift-etcd", "route"}, Priority: 20},
+			{IncludeAll: []string{"ns/openshift-etcd", "ingress"}, Priority: 60},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		test          v1.TestInfo
+		wantComponent string
+		wantPriority  int
+	}{
+		{
+			name:          "override below the namespace priority loses",
+			test:          v1.TestInfo{Name: "disruption/route connection should be available in ns/openshift-etcd"},
+			wantComponent: "Etcd",
+			wantPriority:  50,
+		},
+		{
+			name:          "override above the namespace priority wins",
+			test:          v1.TestInfo{Name: "disruption/ingress connection should be available in ns/openshift-etcd"},
+			wantComponent: "Router",
+			wantPriority:  60,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, m := Resolve([]*Component{etcd, router}, &tt.test)
+			if c == nil {
+				t.Fatalf("Resolve() did not match")
+			}
+			if c.Name != tt.wantComponent || m.EffectivePriority() != tt.wantPriority {
+				t.Errorf("Resolve() = %q at priority %d, want %q at priority %d", c.Name, m.EffectivePriority(), tt.wantComponent, tt.wantPriority)
+			}
+		})
+	}
+
+	if got := (&Component{Namespaces: []string{"openshift-etcd"}}).FindMatch(&tests[0].test); got == nil || got.EffectivePriority() != PriorityNamespace {
+		t.Errorf("FindMatch() = %+v, want a match at PriorityNamespace by default", got)
+	}
+}
+
+func TestComponent_FindMatchHighestPriorityMatcher(t *testing.T) {
+	component := &Component{
+		DefaultJiraComponent: "Networking",
+		Matchers: []ComponentMatcher{
+			{SIG: "sig-network", JiraComponent: "Networking"},
+			{IncludeAll: []string{"ovn"}, JiraComponent: "Networking / ovn-kubernetes", Priority: 2},
+			{IncludeAll: []string{"egress"}, JiraComponent: "Networking / egress", Priority: 2},
+			{IncludeAll: []string{"dns"}, JiraComponent: "Networking / DNS", Priority: 1},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		test      v1.TestInfo
+		wantIndex int
+	}{
+		{
+			name:      "only one matcher matches",
+			test:      v1.TestInfo{Name: "[sig-network] services should work"},
+			wantIndex: 0,
+		},
+		{
+			name:      "higher priority matcher wins over earlier matcher",
+			test:      v1.TestInfo{Name: "[sig-network] dns should resolve"},
+			wantIndex: 3,
+		},
+		{
+			name:      "highest priority wins",
+			test:      v1.TestInfo{Name: "[sig-network] ovn dns should resolve"},
+			wantIndex: 1,
+		},
+		{
+			name:      "first matcher wins priority ties",
+			test:      v1.TestInfo{Name: "[sig-network] ovn egress should route"},
+			wantIndex: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := component.FindMatchWithReason(&tt.test)
+			if got == nil {
+				t.Fatalf("FindMatchWithReason() did not match")
+			}
+			if reason.MatcherIndex != tt.wantIndex {
+				t.Errorf("FindMatchWithReason() matcher index = %d, want %d", reason.MatcherIndex, tt.wantIndex)
+			}
+			if !reflect.DeepEqual(*got, component.Matchers[tt.wantIndex]) {
+				t.Errorf("FindMatchWithReason() = %+v, want %+v", *got, component.Matchers[tt.wantIndex])
+			}
+		})
+	}
+}
+
+func TestComponent_FindMatchOwnsSIG(t *testing.T) {
+	component := &Component{
+		DefaultJiraComponent: "Networking",
+		OwnsSIG:              []string{"sig-network", "sig-network-edge"},
+		OwnsSIGPriority:      5,
+		Namespaces:           []string{"openshift-multus"},
+		Matchers: []ComponentMatcher{
+			{IncludeAll: []string{"ovn"}, JiraComponent: "Networking / ovn-kubernetes"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		test         v1.TestInfo
+		wantSource   MatchSource
+		wantPriority int
+		wantJira     string
+	}{
+		{
+			name:         "matchers are tried first",
+			test:         v1.TestInfo{Name: "[sig-network] ovn pods should be ready"},
+			wantSource:   MatchSourceMatcher,
+			wantPriority: PriorityDefault,
+			wantJira:     "Networking / ovn-kubernetes",
+		},
+		{
+			name:         "owned sig",
+			test:         v1.TestInfo{Name: "[sig-network-edge] routes should be reachable"},
+			wantSource:   MatchSourceSIG,
+			wantPriority: 5,
+			wantJira:     "Networking",
+		},
+		{
+			name:         "owned sig before namespace",
+			test:         v1.TestInfo{Name: "[sig-network] pods should be ready in ns/openshift-multus"},
+			wantSource:   MatchSourceSIG,
+			wantPriority: 5,
+			wantJira:     "Networking",
+		},
+		{
+			name:         "namespace when the test isn't in an owned sig",
+			test:         v1.TestInfo{Name: "[sig-arch] pods should be ready in ns/openshift-multus"},
+			wantSource:   MatchSourceNamespace,
+			wantPriority: PriorityNamespace,
+			wantJira:     "Networking",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, reason := component.FindMatchWithReason(&tt.test)
+			if m == nil {
+				t.Fatalf("FindMatchWithReason() did not match")
+			}
+			if reason.Source != tt.wantSource || m.Priority != tt.wantPriority || m.JiraComponent != tt.wantJira {
+				t.Errorf("FindMatchWithReason() = source %q priority %d jira %q, want source %q priority %d jira %q",
+					reason.Source, m.Priority, m.JiraComponent, tt.wantSource, tt.wantPriority, tt.wantJira)
+			}
+		})
+	}
+}
+
+func TestComponent_FindMatchCapabilities(t *testing.T) {
+	component := &Component{
+		DefaultJiraComponent: "Etcd",
+		Operators:            []string{"etcd"},
+		Matchers: []ComponentMatcher{
+			{IncludeAny: []string{"Operator upgrade etcd"}, Capabilities: []string{"upgrade", "Quorum"}},
+			{IncludeAny: []string{"bz-etcd"}, Capabilities: []string{"Backup"}},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		test             v1.TestInfo
+		wantCapabilities []string
+	}{
+		{
+			name:             "operator test merges matcher capabilities",
+			test:             v1.TestInfo{Name: "Cluster upgrade.Operator upgrade etcd"},
+			wantCapabilities: []string{"Quorum", "upgrade"},
+		},
+		{
+			name:             "operator test without matching matcher",
+			test:             v1.TestInfo{Name: "operator install etcd"},
+			wantCapabilities: []string{"install"},
+		},
+		{
+			name:             "matcher test without operator",
+			test:             v1.TestInfo{Name: "[bz-etcd] should take a backup"},
+			wantCapabilities: []string{"Backup"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := component.FindMatch(&tt.test)
+			if got == nil {
+				t.Fatalf("FindMatch() did not match")
+			}
+			if !reflect.DeepEqual(got.Capabilities, tt.wantCapabilities) {
+				t.Errorf("FindMatch() capabilities = %v, want %v", got.Capabilities, tt.wantCapabilities)
+			}
+		})
+	}
+}
+
+func TestComponent_IsOperatorTest(t *testing.T) {
+	component := &Component{
+		DefaultJiraComponent: "Networking / router",
+		Operators:            []string{"ingress"},
+		OperatorTestPatterns: map[string][]string{
+			"ingress": {
+				`ingress operator should (?P<Capability>install|upgrade) cleanly`,
+				`ingresscontroller .* should be admitted`,
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		test             v1.TestInfo
+		wantOperator     bool
+		wantCapabilities []string
+	}{
+		{
+			name:             "built-in heuristics still apply",
+			test:             v1.TestInfo{Name: "Operator upgrade ingress"},
+			wantOperator:     true,
+			wantCapabilities: []string{"upgrade"},
+		},
+		{
+			name:             "custom pattern with capability",
+			test:             v1.TestInfo{Name: "[sig-network-edge] ingress operator should install cleanly"},
+			wantOperator:     true,
+			wantCapabilities: []string{"install"},
+		},
+		{
+			name:         "custom pattern without capability",
+			test:         v1.TestInfo{Name: "[sig-network-edge] ingresscontroller default should be admitted"},
+			wantOperator: true,
+		},
+		{
+			name: "not an operator test",
+			test: v1.TestInfo{Name: "[sig-network-edge] routes should be reachable"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, compile := range []bool{false, true} {
+				c := *component
+				if compile {
+					if err := c.Compile(); err != nil {
+						t.Fatalf("Compile() returned error: %v", err)
+					}
+				}
+				isOperator, capabilities := c.IsOperatorTest(&tt.test)
+				if isOperator != tt.wantOperator || !reflect.DeepEqual(capabilities, tt.wantCapabilities) {
+					t.Errorf("IsOperatorTest() (compiled=%v) = %v, %v, want %v, %v", compile, isOperator, capabilities, tt.wantOperator, tt.wantCapabilities)
+				}
+			}
+		})
+	}
+}
+
+func TestComponentMatcher_IsSubstringTest(t *testing.T) {
+	cm := &ComponentMatcher{}
+	test := &v1.TestInfo{Name: "[sig-api-machinery] API priority and fairness should work [apigroup:flowcontrol.apiserver.k8s.io]"}
+
+	if !cm.IsSubstringAllTest([]string{"i/PRIORITY AND FAIRNESS", "API"}, test) {
+		t.Errorf("IsSubstringAllTest() = false, want true")
+	}
+	if cm.IsSubstringAllTest([]string{"i/PRIORITY AND FAIRNESS", "api priority"}, test) {
+		t.Errorf("IsSubstringAllTest() with exact entry in the wrong case = true, want false")
+	}
+	if !cm.IsSubstringAllTest([]string{"APF|i/PRIORITY AND FAIRNESS", "API"}, test) {
+		t.Errorf("IsSubstringAllTest() with an alternation = false, want true")
+	}
+	if cm.IsSubstringAllTest([]string{"APF|flowcontrol.apiserver", "etcd"}, test) {
+		t.Errorf("IsSubstringAllTest() with an alternation and an unmatched entry = true, want false")
+	}
+	if !cm.IsSubstringAnyTest([]string{"APF", "i/Flowcontrol"}, test) {
+		t.Errorf("IsSubstringAnyTest() = false, want true")
+	}
+	if cm.IsSubstringAnyTest([]string{"APF", "Flowcontrol"}, test) {
+		t.Errorf("IsSubstringAnyTest() with only exact entries = true, want false")
+	}
+}
+
+func TestComponent_OperatorConfigs(t *testing.T) {
+	component := &Component{
+		DefaultJiraComponent: "Networking / ovn-kubernetes",
+		Operators:            []string{"network"},
+		OperatorConfigs: []OperatorConfig{
+			{Name: "network", Capabilities: []string{"SDN"}},
+			{Name: "ovn-kubernetes", Capabilities: []string{"OVN", "Install"}},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		test             v1.TestInfo
+		wantOperator     bool
+		wantCapabilities []string
+	}{
+		{
+			name:             "operator in both lists gets configured capabilities",
+			test:             v1.TestInfo{Name: "Operator upgrade network"},
+			wantOperator:     true,
+			wantCapabilities: []string{"SDN", "upgrade"},
+		},
+		{
+			name:             "operator only in configs",
+			test:             v1.TestInfo{Name: "operator install ovn-kubernetes"},
+			wantOperator:     true,
+			wantCapabilities: []string{"Install", "OVN", "install"},
+		},
+		{
+			name: "not an operator test",
+			test: v1.TestInfo{Name: "operator install etcd"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isOperator, capabilities := component.IsOperatorTest(&tt.test)
+			if isOperator != tt.wantOperator || !reflect.DeepEqual(capabilities, tt.wantCapabilities) {
+				t.Errorf("IsOperatorTest() = %v, %v, want %v, %v", isOperator, capabilities, tt.wantOperator, tt.wantCapabilities)
+			}
+		})
+	}
+}
+
+func TestComponent_JiraComponents(t *testing.T) {
+	tests := []struct {
+		name      string
+		component Component
+		want      []string
+	}{
+		{
+			name:      "default only",
+			component: Component{DefaultJiraComponent: "Etcd"},
+			want:      []string{"Etcd"},
+		},
+		{
+			name: "union of default and matcher components is sorted and de-duplicated",
+			component: Component{
+				DefaultJiraComponent: "Networking / router",
+				Matchers: []ComponentMatcher{
+					{SIG: "sig-network-edge"},
+					{IncludeAll: []string{"dns"}, JiraComponent: "Networking / DNS"},
+					{IncludeAll: []string{"ingress"}, JiraComponent: "Networking / router"},
+					{IncludeAll: []string{"dns-operator"}, JiraComponent: "Networking / DNS"},
+				},
+			},
+			want: []string{"Networking / DNS", "Networking / router"},
+		},
+		{
+			name:      "no components",
+			component: Component{Matchers: []ComponentMatcher{{SIG: "sig-etcd"}}},
+			want:      []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.component.JiraComponents(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JiraComponents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponent_Aliases(t *testing.T) {
+	component := &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking / ovn-kubernetes",
+		Aliases:              []string{"Networking / SDN"},
+		Matchers:             []ComponentMatcher{{IncludeAll: []string{"dns"}, JiraComponent: "Networking / DNS"}},
+	}
+
+	m, reason := component.FindMatchWithReason(&v1.TestInfo{Name: `[Jira:"Networking / SDN"] pods should be ready`})
+	if m == nil || reason.Source != MatchSourceJiraField {
+		t.Fatalf("FindMatchWithReason() = %+v, %+v, want a jira field match on the old name", m, reason)
+	}
+	if m.JiraComponent != "Networking / ovn-kubernetes" || reason.JiraComponent != "Networking / SDN" {
+		t.Errorf("FindMatchWithReason() assigned %q for jira field %q, want the renamed jira component", m.JiraComponent, reason.JiraComponent)
+	}
+
+	if got, want := component.JiraComponents(), []string{"Networking / DNS", "Networking / ovn-kubernetes"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("JiraComponents() = %v, want %v without aliases", got, want)
+	}
+	for name, want := range map[string]bool{
+		"Networking / SDN":            true,
+		"Networking / ovn-kubernetes": true,
+		"Networking / DNS":            true,
+		"Networking / router":         false,
+	} {
+		if got := component.HasJiraComponent(name); got != want {
+			t.Errorf("HasJiraComponent(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestComponent_CanonicalName(t *testing.T) {
+	component := &Component{
+		TestRenames: map[string]string{
+			"[sig-etcd] etcd 4.15 should be healthy": "[sig-etcd] etcd is healthy",
+		},
+		TestRenameRules: []RenameRule{
+			{
+				Pattern:     `etcd (\d+)\.(\d+) should`,
+				Replacement: "etcd should",
+			},
+			{
+				Pattern:     `\[MinimumKubeletVersion:[^\]]+\]`,
+				Replacement: "",
+			},
+			{
+				Pattern:     `should recover from (?P<failure>\w+) failure`,
+				Replacement: "recovers from ${failure} failure",
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		test string
+		want string
+	}{
+		{
+			name: "exact renames are used before rules",
+			test: "[sig-etcd] etcd 4.15 should be healthy",
+			want: "[sig-etcd] etcd is healthy",
+		},
+		{
+			name: "rule rename",
+			test: "[sig-etcd] etcd 4.16 should be fast",
+			want: "[sig-etcd] etcd should be fast",
+		},
+		{
+			name: "rules are applied in order",
+			test: "[sig-etcd] etcd 4.16 should be fast [MinimumKubeletVersion:1.27]",
+			want: "[sig-etcd] etcd should be fast ",
+		},
+		{
+			name: "rule rename with named capture group",
+			test: "[sig-etcd] should recover from quorum failure",
+			want: "[sig-etcd] recovers from quorum failure",
+		},
+		{
+			name: "no rename",
+			test: "[sig-etcd] should be healthy",
+			want: "[sig-etcd] should be healthy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := component.CanonicalName(tt.test); got != tt.want {
+				t.Errorf("CanonicalName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if err := component.Compile(); err != nil {
+		t.Fatalf("Compile() returned unexpected error: %+v", err)
+	}
+	for _, tt := range tests {
+		if got := component.CanonicalName(tt.test); got != tt.want {
+			t.Errorf("compiled CanonicalName(%q) = %q, want %q", tt.test, got, tt.want)
+		}
+	}
+}
+
+func TestComponent_ResolveRename(t *testing.T) {
+	component := &Component{
+		TestRenames: map[string]string{
+			"test C":            "test B",
+			"test B":            "test A",
+			"cycle 1":           "cycle 2",
+			"cycle 2":           "cycle 3",
+			"cycle 3":           "cycle 1",
+			"upgrade 4.16 test": "upgrade test",
+		},
+		TestRenameRules: []RenameRule{
+			{Pattern: `\d+\.\d+ `, Replacement: ""},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		test      string
+		want      string
+		wantError bool
+	}{
+		{
+			name: "single rename",
+			test: "test B",
+			want: "test A",
+		},
+		{
+			name: "chained renames",
+			test: "test C",
+			want: "test A",
+		},
+		{
+			name: "rule rename followed by exact rename",
+			test: "upgrade 4.17 test",
+			want: "upgrade test",
+		},
+		{
+			name: "not renamed",
+			test: "test A",
+			want: "test A",
+		},
+		{
+			name:      "cycle returns the last name before the cycle",
+			test:      "cycle 1",
+			want:      "cycle 3",
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := component.ResolveRename(tt.test)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("ResolveRename() error = %v, wantError %v", err, tt.wantError)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveRename() = %q, want %q", got, tt.want)
+			}
+			if canonical := component.CanonicalName(tt.test); canonical != tt.want {
+				t.Errorf("CanonicalName() = %q, want %q", canonical, tt.want)
+			}
+		})
+	}
+
+	err := component.Validate()
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Errorf("Validate() = %v, want a single rename cycle error", err)
+	}
+}
+
+func TestComponent_NamespacePatterns(t *testing.T) {
+	component := &Component{
+		DefaultJiraComponent: "Networking",
+		Namespaces:           []string{"openshift-ovn-kubernetes", "openshift-multus"},
+		NamespacePatterns: []string{
+			`in namespace "(?P<Namespace>[-\w]+)"`,
+			`\[namespace=(?P<Namespace>[-\w]+)\]`,
+		},
+	}
+
+	tests := []struct {
+		name          string
+		test          string
+		wantNamespace string
+		wantMatch     bool
+	}{
+		{
+			name:          "built-in short form",
+			test:          "alert/KubePodNotReady should not be at or above info in ns/openshift-multus",
+			wantNamespace: "openshift-multus",
+			wantMatch:     true,
+		},
+		{
+			name:          "custom quoted form",
+			test:          `pods should be ready in namespace "openshift-ovn-kubernetes"`,
+			wantNamespace: "openshift-ovn-kubernetes",
+			wantMatch:     true,
+		},
+		{
+			name:          "custom bracket form",
+			test:          "[sig-network][namespace=openshift-multus] pods should be ready",
+			wantNamespace: "openshift-multus",
+			wantMatch:     true,
+		},
+		{
+			name:          "custom form for a namespace owned by someone else",
+			test:          "[sig-network][namespace=openshift-dns] pods should be ready",
+			wantNamespace: "openshift-dns",
+		},
+		{
+			name: "no namespace",
+			test: "[sig-network] pods should be ready",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, _ := component.IsNamespaceTest(tt.test)
+			if namespace != tt.wantNamespace {
+				t.Errorf("IsNamespaceTest() = %q, want %q", namespace, tt.wantNamespace)
+			}
+			if got := component.FindMatch(&v1.TestInfo{Name: tt.test}); (got != nil) != tt.wantMatch {
+				t.Errorf("FindMatch() matched = %v, want %v", got != nil, tt.wantMatch)
+			}
+		})
+	}
+
+	component.NamespacePatterns = append(component.NamespacePatterns, `namespace (\w+)`)
+	if err := component.Compile(); err == nil {
+		t.Errorf("Compile() did not return an error for a pattern without a Namespace group")
+	}
+}
+
+func TestComponent_JiraComponentRegex(t *testing.T) {
+	component := &Component{
+		DefaultJiraComponent: "Networking / DNS",
+		Aliases:              []string{"DNS"},
+		Namespaces:           []string{"openshift-dns"},
+		JiraComponentRegex:   `\(owner: (?P<Component>[^)]+)\)`,
+		Matchers: []ComponentMatcher{
+			{IncludeAll: []string{"coredns"}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		test       string
+		wantSource MatchSource
+		wantJira   string
+	}{
+		{
+			name:       "captured default jira component",
+			test:       "resolver should answer (owner: Networking / DNS)",
+			wantSource: MatchSourceJiraRegex,
+			wantJira:   "Networking / DNS",
+		},
+		{
+			name:       "captured alias",
+			test:       "resolver should answer (owner: dns)",
+			wantSource: MatchSourceJiraRegex,
+			wantJira:   "dns",
+		},
+		{
+			name: "captured another jira component",
+			test: "resolver should answer (owner: Networking / router)",
+		},
+		{
+			name: "no capture falls through",
+			test: "resolver should answer",
+		},
+		{
+			name:       "matchers claim first",
+			test:       "coredns should answer (owner: Networking / DNS)",
+			wantSource: MatchSourceMatcher,
+		},
+		{
+			name:       "namespaces claim first",
+			test:       "pods should be ready in ns/openshift-dns (owner: Networking / DNS)",
+			wantSource: MatchSourceNamespace,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, reason := component.FindMatchWithReason(&v1.TestInfo{Name: tt.test})
+			if (m != nil) != (tt.wantSource != "") {
+				t.Fatalf("FindMatchWithReason() matched = %v, want %v", m != nil, tt.wantSource != "")
+			}
+			if reason.Source != tt.wantSource {
+				t.Errorf("FindMatchWithReason() source = %q, want %q", reason.Source, tt.wantSource)
+			}
+			if reason.Source == MatchSourceJiraRegex && reason.JiraComponent != tt.wantJira {
+				t.Errorf("FindMatchWithReason() jira component = %q, want %q", reason.JiraComponent, tt.wantJira)
+			}
+		})
+	}
+
+	component.JiraComponentRegex = `owner: (\w+)`
+	if err := component.Compile(); err == nil {
+		t.Errorf("Compile() did not return an error for a pattern without a Component group")
+	}
+}
+
+func TestIHateRegexes(t *testing.T) {
+	actual := ExtractNamespaceFromTestName("[sig-arch][bz-Unknown][Late] Alerts [apigroup:monitoring.coreos.com] alert/KubePodNotReady should not be at or above info in ns/openshift [Suite:openshift/conformance/parallel]")
+	if actual != "openshift" {
+		t.Fatal(actual)
+	}
+	actual = ExtractNamespaceFromTestName("[sig-arch][bz-openshift-apiserver][Late] Alerts [apigroup:monitoring.coreos.com] alert/KubePodNotReady should not be at or above info in ns/openshift-apiserver [Suite:openshift/conformance/parallel]")
+	if actual != "openshift-apiserver" {
+		t.Fatal(actual)
+	}
+}
+
+func TestExtractNamespacesFromTestName(t *testing.T) {
+	tests := []struct {
+		name string
+		test string
+		want []string
+	}{
+		{
+			name: "single namespace",
+			test: "alert/KubePodNotReady should not be at or above info in ns/openshift-etcd",
+			want: []string{"openshift-etcd"},
+		},
+		{
+			name: "multiple namespaces in order",
+			test: "should copy secrets from namespace/openshift-config to ns/openshift-etcd",
+			want: []string{"openshift-config", "openshift-etcd"},
+		},
+		{
+			name: "duplicates are removed",
+			test: "ns/openshift-etcd to ns/openshift-config and back to ns/openshift-etcd",
+			want: []string{"openshift-etcd", "openshift-config"},
+		},
+		{
+			name: "upper case short prefix",
+			test: "alert/KubePodNotReady should not be at or above info in NS/openshift-etcd",
+			want: []string{"openshift-etcd"},
+		},
+		{
+			name: "title case full prefix",
+			test: "should copy secrets from Namespace/openshift-config",
+			want: []string{"openshift-config"},
+		},
+		{
+			name: "mixed case prefixes keep the namespace verbatim",
+			test: "should copy secrets from nAmEsPaCe/Openshift-Config to Ns/openshift-ETCD",
+			want: []string{"Openshift-Config", "openshift-ETCD"},
+		},
+		{
+			name: "no namespaces",
+			test: "[sig-etcd] should be healthy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractNamespacesFromTestName(tt.test); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractNamespacesFromTestName() = %v, want %v", got, tt.want)
+			}
+			wantFirst := ""
+			if len(tt.want) > 0 {
+				wantFirst = tt.want[0]
+			}
+			if got := ExtractNamespaceFromTestName(tt.test); got != wantFirst {
+				t.Errorf("ExtractNamespaceFromTestName() = %q, want %q", got, wantFirst)
+			}
+		})
+	}
+
+	component := &Component{
+		DefaultJiraComponent: "Etcd",
+		Namespaces:           []string{"openshift-etcd"},
+	}
+	test := &v1.TestInfo{Name: "should copy secrets from ns/openshift-config to ns/openshift-etcd"}
+	if got, reason := component.FindMatchWithReason(test); got == nil || reason.Namespace != "openshift-etcd" {
+		t.Errorf("FindMatchWithReason() = %+v, %+v, want a match on namespace openshift-etcd", got, reason)
+	}
+}
+
+func TestRegisterNamespacePattern(t *testing.T) {
+	defer ResetNamespacePatterns()
+
+	test := `pods in namespace "openshift-etcd" should be ready`
+	if got := ExtractNamespaceFromTestName(test); got != "" {
+		t.Fatalf("ExtractNamespaceFromTestName() before registering = %q, want none", got)
+	}
+
+	if err := RegisterNamespacePattern(`in namespace "[-\w]+"`); err == nil {
+		t.Errorf("RegisterNamespacePattern() without a Namespace group returned no error")
+	}
+	if err := RegisterNamespacePattern(`in namespace "(?P<Namespace>[-\w]+`); err == nil {
+		t.Errorf("RegisterNamespacePattern() with an invalid pattern returned no error")
+	}
+
+	// Registering must be safe while namespaces are being extracted.
+	component := &Component{DefaultJiraComponent: "Etcd", Namespaces: []string{"openshift-etcd"}}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				component.FindMatch(&v1.TestInfo{Name: test})
+			}
+		}()
+	}
+	if err := RegisterNamespacePattern(`in namespace "(?P<Namespace>[-\w]+)"`); err != nil {
+		t.Fatalf("RegisterNamespacePattern() returned error: %v", err)
+	}
+	wg.Wait()
+
+	if got := ExtractNamespaceFromTestName(test); got != "openshift-etcd" {
+		t.Errorf("ExtractNamespaceFromTestName() after registering = %q, want openshift-etcd", got)
+	}
+	if m := component.FindMatch(&v1.TestInfo{Name: test}); m == nil {
+		t.Errorf("FindMatch() after registering did not match")
+	}
+
+	ResetNamespacePatterns()
+	if got := ExtractNamespaceFromTestName(test); got != "" {
+		t.Errorf("ExtractNamespaceFromTestName() after resetting = %q, want none", got)
+	}
+}
+
+func TestComponent_FindMatchRegexCapabilities(t *testing.T) {
+	c := &Component{
+		Name:                 "Etcd",
+		DefaultJiraComponent: "Etcd",
+		Matchers: []ComponentMatcher{
+			{
+				IncludeRegex: []string{`etcd operator (?P<phase>install|upgrade)( with (\w+))? should succeed`},
+				Capabilities: []string{"Operator", "$phase", "$3"},
+			},
+		},
+	}
+	if err := c.Compile(); err != nil {
+		t.Fatalf("Compile() returned error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{
+			name: "[sig-etcd] etcd operator install should succeed",
+			want: []string{"Operator", "install"},
+		},
+		{
+			name: "[sig-etcd] etcd operator upgrade with aescbc should succeed",
+			want: []string{"Operator", "upgrade", "aescbc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := c.FindMatch(&v1.TestInfo{Name: tt.name})
+			if m == nil {
+				t.Fatalf("FindMatch() = nil, want a match")
+			}
+			if !reflect.DeepEqual(m.Capabilities, tt.want) {
+				t.Errorf("FindMatch() capabilities = %q, want %q", m.Capabilities, tt.want)
+			}
+		})
+	}
+
+	if got, want := c.Matchers[0].Capabilities, []string{"Operator", "$phase", "$3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindMatch() modified the matcher's capabilities to %q", got)
+	}
+}
+
+func TestComponent_CompileRegexCapabilities(t *testing.T) {
+	for _, m := range []ComponentMatcher{
+		{IncludeRegex: []string{`etcd operator (install`}},
+		{IncludeAll: []string{"etcd operator"}, Capabilities: []string{"$1"}},
+	} {
+		c := &Component{DefaultJiraComponent: "Etcd", Matchers: []ComponentMatcher{m}}
+		if err := c.Compile(); err == nil {
+			t.Errorf("Compile() did not return an error for %+v", m)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		matcher ComponentMatcher
+		wantErr string
+	}{
+		{
+			name:    "groups that exist",
+			matcher: ComponentMatcher{IncludeRegex: []string{`etcd (?P<phase>install) (\w+)`}, Capabilities: []string{"$1", "${2}", "$phase-$$3", "$-"}},
+		},
+		{
+			name:    "index past the groups",
+			matcher: ComponentMatcher{IncludeRegex: []string{`etcd (install)`}, Capabilities: []string{"$2"}},
+			wantErr: `component "Etcd" matcher 0: capability "$2" refers to capture group "2", which includeRegex "etcd (install)" doesn't have`,
+		},
+		{
+			name:    "unknown name",
+			matcher: ComponentMatcher{IncludeRegex: []string{`etcd (?P<phase>install)`}, Capabilities: []string{"${stage}"}},
+			wantErr: `component "Etcd" matcher 0: capability "${stage}" refers to capture group "stage", which includeRegex "etcd (?P<phase>install)" doesn't have`,
+		},
+		{
+			name:    "name running into text",
+			matcher: ComponentMatcher{IncludeRegex: []string{`etcd (install)`}, Capabilities: []string{"$1x"}},
+			wantErr: `component "Etcd" matcher 0: capability "$1x" refers to capture group "1x", which includeRegex "etcd (install)" doesn't have`,
+		},
+		{
+			name:    "negated",
+			matcher: ComponentMatcher{IncludeRegex: []string{`etcd (install)`}, Capabilities: []string{"$1"}, Negate: true},
+			wantErr: `component "Etcd" matcher 0: capability "$1" refers to a capture group, but the matcher is negated, so its includeRegex doesn't match the tests it claims`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Component{Name: "Etcd", DefaultJiraComponent: "Etcd", Matchers: []ComponentMatcher{tt.matcher}}
+			err := c.Compile()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Compile() returned error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Compile() error = %v, want %s", err, tt.wantErr)
+			}
+			if err := c.Validate(); err == nil || !strings.Contains(err.Error(), "capture group") {
+				t.Errorf("Validate() error = %v, want a capture group error", err)
+			}
+		})
+	}
+}
+
+func TestComponent_TestID(t *testing.T) {
+	component := &Component{
+		TestRenames: map[string]string{
+			"[sig-etcd] etcd is healthy after rename": "[sig-etcd] etcd is healthy",
+		},
+	}
+
+	tests := []struct {
+		name string
+		test v1.TestInfo
+		want string
+	}{
+		{
+			name: "canonical name",
+			test: v1.TestInfo{Name: "[sig-etcd] etcd is healthy", Suite: "openshift-tests"},
+			want: "6e123e4e5cf49af894a6cd0b138153b0",
+		},
+		{
+			name: "renamed test has the canonical name's ID",
+			test: v1.TestInfo{Name: "[sig-etcd] etcd is healthy after rename", Suite: "openshift-tests"},
+			want: "6e123e4e5cf49af894a6cd0b138153b0",
+		},
+		{
+			name: "no suite",
+			test: v1.TestInfo{Name: "[sig-etcd] etcd is healthy"},
+			want: "182bb5ac87554c3ab1c572f93c302c2d",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := component.TestID(&tt.test); got != tt.want {
+				t.Errorf("TestID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponent_JiraProjectFor(t *testing.T) {
+	component := &Component{
+		Name:                 "Networking",
+		DefaultJiraProject:   "OCPBUGS",
+		DefaultJiraComponent: "Networking",
+		Namespaces:           []string{"openshift-multus"},
+		Matchers: []ComponentMatcher{
+			{IncludeAll: []string{"ovn-kubernetes"}, JiraProject: "CORENET", JiraComponent: "ovn-kubernetes"},
+			{IncludeAll: []string{"multus"}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "[sig-network] ovn-kubernetes should route pods", want: "CORENET"},
+		{name: "[sig-network] multus should attach networks", want: "OCPBUGS"},
+		{name: "[sig-arch] pods should be ready in ns/openshift-multus", want: "OCPBUGS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := component.FindMatch(&v1.TestInfo{Name: tt.name})
+			if m == nil {
+				t.Fatalf("FindMatch() = nil, want a match")
+			}
+			if got := component.JiraProjectFor(m); got != tt.want {
+				t.Errorf("JiraProjectFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := component.JiraProjectFor(nil); got != "OCPBUGS" {
+		t.Errorf("JiraProjectFor(nil) = %q, want OCPBUGS", got)
 	}
 }
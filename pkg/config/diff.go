@@ -0,0 +1,51 @@
+package config
+
+import (
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// MappingChange describes a test whose ownership differs between two configurations.
+// Component and Jira component names are empty when no component owns the test.
+type MappingChange struct {
+	TestName string
+	Suite    string
+
+	OldComponent     string
+	NewComponent     string
+	OldJiraComponent string
+	NewJiraComponent string
+}
+
+// DiffMappings resolves the owner of every test in the corpus under both the old and the
+// new components, see Resolve, and returns the tests whose owning component or Jira
+// component changed. It's intended for reviewing changes to components before they're
+// merged.
+func DiffMappings(old, new []*Component, tests []*v1.TestInfo) []MappingChange {
+	var changes []MappingChange
+	for _, test := range tests {
+		oldComponent, oldJira := resolveNames(old, test)
+		newComponent, newJira := resolveNames(new, test)
+		if oldComponent == newComponent && oldJira == newJira {
+			continue
+		}
+		changes = append(changes, MappingChange{
+			TestName:         test.Name,
+			Suite:            test.Suite,
+			OldComponent:     oldComponent,
+			NewComponent:     newComponent,
+			OldJiraComponent: oldJira,
+			NewJiraComponent: newJira,
+		})
+	}
+	return changes
+}
+
+// resolveNames returns the names of the component and Jira component that own the test,
+// or empty strings if no component does.
+func resolveNames(components []*Component, test *v1.TestInfo) (string, string) {
+	component, matcher := Resolve(components, test)
+	if component == nil {
+		return "", ""
+	}
+	return component.Name, component.jiraComponentFor(matcher)
+}
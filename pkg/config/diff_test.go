@@ -0,0 +1,66 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestDiffMappings(t *testing.T) {
+	old := []*Component{
+		{
+			Name:                 "Networking",
+			DefaultJiraComponent: "Networking",
+			Matchers: []ComponentMatcher{
+				{SIG: "sig-network"},
+			},
+		},
+	}
+	new := []*Component{
+		{
+			Name:                 "Networking",
+			DefaultJiraComponent: "Networking",
+			Matchers: []ComponentMatcher{
+				{SIG: "sig-network"},
+				{IncludeAll: []string{"ovn"}, JiraComponent: "Networking / ovn-kubernetes", Priority: 1},
+			},
+		},
+		{
+			Name:                 "Storage",
+			DefaultJiraComponent: "Storage",
+			Matchers: []ComponentMatcher{
+				{SIG: "sig-storage"},
+			},
+		},
+	}
+	tests := []*v1.TestInfo{
+		{Name: "[sig-network] services should work"},
+		{Name: "[sig-network] ovn pods should be ready", Suite: "openshift-tests"},
+		{Name: "[sig-storage] volumes should mount"},
+		{Name: "[sig-arch] should be unowned"},
+	}
+
+	want := []MappingChange{
+		{
+			TestName:         "[sig-network] ovn pods should be ready",
+			Suite:            "openshift-tests",
+			OldComponent:     "Networking",
+			NewComponent:     "Networking",
+			OldJiraComponent: "Networking",
+			NewJiraComponent: "Networking / ovn-kubernetes",
+		},
+		{
+			TestName:         "[sig-storage] volumes should mount",
+			NewComponent:     "Storage",
+			NewJiraComponent: "Storage",
+		},
+	}
+	if got := DiffMappings(old, new, tests); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffMappings() = %+v, want %+v", got, want)
+	}
+
+	if got := DiffMappings(new, new, tests); len(got) != 0 {
+		t.Errorf("DiffMappings() of identical components = %+v, want none", got)
+	}
+}
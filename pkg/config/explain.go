@@ -0,0 +1,152 @@
+package config
+
+import (
+	"encoding/json"
+	"sort"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// MatchExplanation is a serializable description of why a component owns a test, e.g.
+// for rendering in a dashboard. It marshals to canonical JSON, see MarshalJSON.
+type MatchExplanation struct {
+	Component     string   `json:"component"`
+	JiraProject   string   `json:"jiraProject,omitempty"`
+	JiraComponent string   `json:"jiraComponent,omitempty"`
+	Capabilities  []string `json:"capabilities,omitempty"`
+	Priority      int      `json:"priority"`
+
+	// Confidence is how sure the claim is, see MatchReason.
+	Confidence Confidence `json:"confidence,omitempty"`
+
+	// Source is the stage of FindMatch that claimed the test.
+	Source MatchSource `json:"source,omitempty"`
+
+	// MatcherIndex is the index of the matcher that claimed the test, for matcher and
+	// custom matches.
+	MatcherIndex *int `json:"matcherIndex,omitempty"`
+
+	// JiraField, Operator and Namespace are what the test was claimed on, for jira-field,
+	// operator and namespace matches respectively.
+	JiraField string `json:"jiraField,omitempty"`
+	Operator  string `json:"operator,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// SIGs, Suite, Variants and Substrings are the matcher values that were satisfied, for
+	// matcher matches. SIGs is also the owned SIG, for sig matches.
+	SIGs       []string `json:"sigs,omitempty"`
+	Suite      string   `json:"suite,omitempty"`
+	Variants   []string `json:"variants,omitempty"`
+	Substrings []string `json:"substrings,omitempty"`
+}
+
+// MarshalJSON marshals the explanation canonically: its lists are sorted, and fields are
+// in a fixed order and omitted when empty, so the same explanation always marshals to the
+// same bytes, e.g. for golden tests.
+func (e MatchExplanation) MarshalJSON() ([]byte, error) {
+	type explanation MatchExplanation
+	canonical := explanation(e)
+	canonical.Capabilities = sortedStrings(e.Capabilities)
+	canonical.SIGs = sortedStrings(e.SIGs)
+	canonical.Variants = sortedStrings(e.Variants)
+	canonical.Substrings = sortedStrings(e.Substrings)
+	return json.Marshal(canonical)
+}
+
+// MarshalJSON marshals the reason canonically, like MatchExplanation, with the same field
+// names in the same order. MatcherIndex is only included for matcher and custom matches.
+func (r MatchReason) MarshalJSON() ([]byte, error) {
+	canonical := struct {
+		Confidence    Confidence  `json:"confidence,omitempty"`
+		Source        MatchSource `json:"source,omitempty"`
+		MatcherIndex  *int        `json:"matcherIndex,omitempty"`
+		JiraComponent string      `json:"jiraField,omitempty"`
+		Operator      string      `json:"operator,omitempty"`
+		Namespace     string      `json:"namespace,omitempty"`
+		SIGs          []string    `json:"sigs,omitempty"`
+		Suite         string      `json:"suite,omitempty"`
+		Variants      []string    `json:"variants,omitempty"`
+		Substrings    []string    `json:"substrings,omitempty"`
+	}{
+		Confidence:    r.Confidence,
+		Source:        r.Source,
+		JiraComponent: r.JiraComponent,
+		Operator:      r.Operator,
+		Namespace:     r.Namespace,
+		SIGs:          sortedStrings(r.SIGs),
+		Suite:         r.Suite,
+		Variants:      sortedStrings(r.Variants),
+		Substrings:    sortedStrings(r.Substrings),
+	}
+	if r.Source == MatchSourceMatcher || r.Source == MatchSourceCustom {
+		index := r.MatcherIndex
+		canonical.MatcherIndex = &index
+	}
+	return json.Marshal(canonical)
+}
+
+// sortedStrings returns a sorted copy of the strings, or nil if there are none.
+func sortedStrings(strs []string) []string {
+	if len(strs) == 0 {
+		return nil
+	}
+	sorted := append([]string{}, strs...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// Explain returns an explanation of why the component owns the test, or nil if it
+// doesn't, see FindMatchWithReason.
+func (c *Component) Explain(test *v1.TestInfo) *MatchExplanation {
+	matcher, reason := c.FindMatchWithReason(test)
+	if matcher == nil {
+		return nil
+	}
+
+	explanation := &MatchExplanation{
+		Component:     c.Name,
+		JiraProject:   c.JiraProjectFor(matcher),
+		JiraComponent: c.jiraComponentFor(matcher),
+		Capabilities:  matcher.Capabilities,
+		Priority:      matcher.EffectivePriority(),
+		Confidence:    reason.Confidence,
+		Source:        reason.Source,
+		JiraField:     reason.JiraComponent,
+		Operator:      reason.Operator,
+		Namespace:     reason.Namespace,
+		SIGs:          reason.SIGs,
+		Suite:         reason.Suite,
+		Variants:      reason.Variants,
+		Substrings:    reason.Substrings,
+	}
+	if reason.Source == MatchSourceMatcher || reason.Source == MatchSourceCustom {
+		index := reason.MatcherIndex
+		explanation.MatcherIndex = &index
+	}
+	return explanation
+}
+
+// jiraComponentFor returns the Jira component assigned by the matcher, which defaults to
+// the component's default Jira component.
+func (c *Component) jiraComponentFor(matcher *ComponentMatcher) string {
+	if matcher.JiraComponent != "" {
+		return matcher.JiraComponent
+	}
+	return c.DefaultJiraComponent
+}
+
+// PredictOwner explains which component will own a test that isn't in the corpus yet,
+// identified by its name, suite and the variants it'll run on, e.g. so a pre-submit check
+// can report the owner of a new test. The owner is resolved among every component, see
+// Resolve. If no component claims the test, the explanation is empty.
+func PredictOwner(components []*Component, name, suite string, variants []string) MatchExplanation {
+	test := &v1.TestInfo{Name: name, Suite: suite, Variants: variants}
+	winner := resolveClaims(findClaims(components, test))
+	if winner == nil {
+		return MatchExplanation{}
+	}
+	if explanation := winner.component.Explain(test); explanation != nil {
+		return *explanation
+	}
+	return MatchExplanation{}
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestComponent_Explain(t *testing.T) {
+	component := &Component{
+		Name:                 "Networking",
+		DefaultJiraProject:   "OCPBUGS",
+		DefaultJiraComponent: "Networking",
+		Namespaces:           []string{"openshift-multus"},
+		Matchers: []ComponentMatcher{
+			{
+				SIG:           "sig-network",
+				IncludeAny:    []string{"egress", "ovn"},
+				JiraProject:   "CORENET",
+				JiraComponent: "Networking / ovn-kubernetes",
+				Capabilities:  []string{"EgressIP"},
+				Priority:      1,
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		test v1.TestInfo
+		want string
+	}{
+		{
+			name: "matcher",
+			test: v1.TestInfo{Name: "[sig-network] ovn pods should be ready"},
+			want: `{"component":"Networking","jiraProject":"CORENET","jiraComponent":"Networking / ovn-kubernetes","capabilities":["EgressIP"],"priority":1,"confidence":"medium","source":"matcher","matcherIndex":0,"sigs":["sig-network"],"substrings":["ovn"]}`,
+		},
+		{
+			name: "namespace",
+			test: v1.TestInfo{Name: "alert/KubePodNotReady should not be at or above info in ns/openshift-multus"},
+			want: `{"component":"Networking","jiraProject":"OCPBUGS","jiraComponent":"Networking","priority":10,"confidence":"medium","source":"namespace","namespace":"openshift-multus"}`,
+		},
+		{
+			name: "jira field",
+			test: v1.TestInfo{Name: `[Jira:"Networking"] pods should be ready`},
+			want: `{"component":"Networking","jiraProject":"OCPBUGS","jiraComponent":"Networking","priority":0,"confidence":"high","source":"jira-field","jiraField":"Networking"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			explanation := component.Explain(&tt.test)
+			if explanation == nil {
+				t.Fatalf("Explain() returned nil")
+			}
+			got, err := json.Marshal(explanation)
+			if err != nil {
+				t.Fatalf("json.Marshal() returned error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Explain() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if explanation := component.Explain(&v1.TestInfo{Name: "[sig-arch] should be unowned"}); explanation != nil {
+		t.Errorf("Explain() = %+v, want nil", explanation)
+	}
+}
+
+func TestPredictOwner(t *testing.T) {
+	networking := &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking",
+		Matchers:             []ComponentMatcher{{SIG: "sig-network"}},
+	}
+	ovn := &Component{
+		Name:                 "OVN",
+		DefaultJiraComponent: "Networking / ovn-kubernetes",
+		Matchers: []ComponentMatcher{
+			{SIG: "sig-network", IncludeAll: []string{"ovn"}, Variants: []string{"NetworkType:OVN"}, Priority: 1},
+		},
+	}
+	components := []*Component{networking, ovn}
+
+	tests := []struct {
+		name          string
+		variants      []string
+		wantComponent string
+		wantJira      string
+	}{
+		{
+			name:          "[sig-network] ovn pods should be ready",
+			variants:      []string{"NetworkType:OVN"},
+			wantComponent: "OVN",
+			wantJira:      "Networking / ovn-kubernetes",
+		},
+		{
+			name:          "[sig-network] ovn pods should be ready",
+			variants:      []string{"NetworkType:SDN"},
+			wantComponent: "Networking",
+			wantJira:      "Networking",
+		},
+		{
+			name: "[sig-arch] should be unowned",
+		},
+	}
+	for _, tt := range tests {
+		got := PredictOwner(components, tt.name, "", tt.variants)
+		if got.Component != tt.wantComponent || got.JiraComponent != tt.wantJira {
+			t.Errorf("PredictOwner(%q, %v) = %+v, want component %q, jira component %q", tt.name, tt.variants, got, tt.wantComponent, tt.wantJira)
+		}
+	}
+}
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestMatchExplanation_Golden(t *testing.T) {
+	components := []*Component{
+		{
+			Name:                 "Networking",
+			DefaultJiraProject:   "OCPBUGS",
+			DefaultJiraComponent: "Networking",
+			DefaultCapabilities:  []string{"Networking"},
+			Namespaces:           []string{"openshift-multus"},
+			Matchers: []ComponentMatcher{
+				{
+					SIGAny:        []string{"sig-network-edge", "sig-network"},
+					IncludeAny:    []string{"ovn", "egress"},
+					Variants:      []string{"Platform:aws", "NetworkType:OVN"},
+					JiraComponent: "Networking / ovn-kubernetes",
+					Capabilities:  []string{"OVN", "EgressIP"},
+					Priority:      PrioritySpecific,
+				},
+			},
+		},
+		{
+			Name:                 "Etcd",
+			DefaultJiraComponent: "Etcd",
+			Operators:            []string{"etcd"},
+		},
+		{
+			Name:                 "Router",
+			DefaultJiraComponent: "Networking / router",
+			OwnsSIG:              []string{"sig-network-edge"},
+		},
+	}
+	tests := []*v1.TestInfo{
+		{Name: "[sig-network] egress ovn pods should be ready", Variants: []string{"Platform:aws", "NetworkType:OVN"}},
+		{Name: "alert/KubePodNotReady should not be at or above info in ns/openshift-multus"},
+		{Name: "[bz-etcd][invariant] operator conditions etcd"},
+		{Name: `[Jira:"Etcd"] should be healthy`},
+		{Name: "[sig-network-edge] routes should be admitted"},
+		{Name: "[sig-arch] should be unowned"},
+	}
+
+	type entry struct {
+		Test        string           `json:"test"`
+		Explanation MatchExplanation `json:"explanation"`
+		Reason      MatchReason      `json:"reason"`
+	}
+	var entries []entry
+	for _, test := range tests {
+		e := entry{Test: test.Name, Explanation: PredictOwner(components, test.Name, test.Suite, test.Variants)}
+		for _, c := range components {
+			if c.Name == e.Explanation.Component {
+				_, e.Reason = c.FindMatchWithReason(test)
+			}
+		}
+		entries = append(entries, e)
+	}
+	got, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		t.Fatalf("json.MarshalIndent() returned error: %v", err)
+	}
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", "explanations.golden.json")
+	if *updateGolden {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("explanations differ from %s, rerun with -update if the change is intended:\n%s", path, got)
+	}
+
+	// Marshaling again, e.g. after FindMatch's lists were built in another order, is
+	// byte-stable.
+	again, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil || !bytes.Equal(append(again, '\n'), got) {
+		t.Errorf("json.MarshalIndent() isn't stable: %v", err)
+	}
+}
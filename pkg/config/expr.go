@@ -0,0 +1,275 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+	"github.com/openshift-eng/ci-test-mapping/pkg/util"
+)
+
+// ExprSyntaxError is a problem parsing a matcher's Expr, at a byte offset into it.
+type ExprSyntaxError struct {
+	Expr   string
+	Offset int
+	Reason string
+}
+
+func (e *ExprSyntaxError) Error() string {
+	return fmt.Sprintf("expr %q: syntax error at offset %d: %s", e.Expr, e.Offset, e.Reason)
+}
+
+// exprNode is a parsed Expr, or part of one.
+type exprNode interface {
+	eval(test *v1.TestInfo) bool
+}
+
+type exprAnd struct{ left, right exprNode }
+
+func (e exprAnd) eval(test *v1.TestInfo) bool { return e.left.eval(test) && e.right.eval(test) }
+
+type exprOr struct{ left, right exprNode }
+
+func (e exprOr) eval(test *v1.TestInfo) bool { return e.left.eval(test) || e.right.eval(test) }
+
+type exprNot struct{ operand exprNode }
+
+func (e exprNot) eval(test *v1.TestInfo) bool { return !e.operand.eval(test) }
+
+// exprContains is a contains("...") predicate, case-insensitive like other substrings
+// with CaseInsensitive or an i/ prefix.
+type exprContains struct{ substring substring }
+
+func (e exprContains) eval(test *v1.TestInfo) bool {
+	name := testName{name: test.Name}
+	if e.substring.caseInsensitive {
+		name.lower = strings.ToLower(test.Name)
+	}
+	return e.substring.in(name)
+}
+
+type exprSIG struct{ sig string }
+
+func (e exprSIG) eval(test *v1.TestInfo) bool { return util.IsSigTest(test.Name, e.sig) }
+
+type exprSuite struct{ suite string }
+
+func (e exprSuite) eval(test *v1.TestInfo) bool { return test.Suite == e.suite }
+
+// exprPredicates are the predicates an Expr may use, by name.
+var exprPredicates = map[string]func(arg string, caseInsensitive bool) exprNode{
+	"contains": func(arg string, caseInsensitive bool) exprNode {
+		return exprContains{substring: compileSubstrings([]string{arg}, caseInsensitive)[0]}
+	},
+	"sig":   func(arg string, _ bool) exprNode { return exprSIG{sig: arg} },
+	"suite": func(arg string, _ bool) exprNode { return exprSuite{suite: arg} },
+}
+
+type exprTokenKind int
+
+const (
+	exprTokenEOF exprTokenKind = iota
+	exprTokenIdent
+	exprTokenString
+	exprTokenLParen
+	exprTokenRParen
+)
+
+type exprToken struct {
+	kind   exprTokenKind
+	value  string
+	offset int
+}
+
+func (t exprToken) String() string {
+	switch t.kind {
+	case exprTokenEOF:
+		return "end of expression"
+	case exprTokenString:
+		return strconv.Quote(t.value)
+	default:
+		return fmt.Sprintf("%q", t.value)
+	}
+}
+
+// exprParser is a recursive descent parser for the Expr grammar, from loosest to tightest
+// binding:
+//
+//	or        = and { "OR" and }
+//	and       = not { "AND" not }
+//	not       = "NOT" not | primary
+//	primary   = "(" or ")" | predicate "(" string ")"
+//	predicate = "contains" | "sig" | "suite"
+//
+// Keywords are case-insensitive, and strings are double-quoted with Go escapes.
+type exprParser struct {
+	expr            string
+	tokens          []exprToken
+	pos             int
+	caseInsensitive bool
+}
+
+// parseExpr parses a matcher's Expr. Its contains predicates are case-insensitive if
+// caseInsensitive is set, or they have an i/ prefix.
+func parseExpr(expr string, caseInsensitive bool) (exprNode, error) {
+	tokens, err := tokenizeExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{expr: expr, tokens: tokens, caseInsensitive: caseInsensitive}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != exprTokenEOF {
+		return nil, p.errorf(next, "unexpected %s", next)
+	}
+	return node, nil
+}
+
+func tokenizeExpr(expr string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, exprToken{kind: exprTokenLParen, value: "(", offset: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, exprToken{kind: exprTokenRParen, value: ")", offset: i})
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(expr) && expr[end] != '"'; end++ {
+				if expr[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(expr) {
+				return nil, &ExprSyntaxError{Expr: expr, Offset: i, Reason: "unterminated string"}
+			}
+			value, err := strconv.Unquote(expr[i : end+1])
+			if err != nil {
+				return nil, &ExprSyntaxError{Expr: expr, Offset: i, Reason: fmt.Sprintf("invalid string %s", expr[i:end+1])}
+			}
+			tokens = append(tokens, exprToken{kind: exprTokenString, value: value, offset: i})
+			i = end + 1
+		case isExprIdentRune(rune(c)):
+			end := i
+			for end < len(expr) && isExprIdentRune(rune(expr[end])) {
+				end++
+			}
+			tokens = append(tokens, exprToken{kind: exprTokenIdent, value: expr[i:end], offset: i})
+			i = end
+		default:
+			return nil, &ExprSyntaxError{Expr: expr, Offset: i, Reason: fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+	return append(tokens, exprToken{kind: exprTokenEOF, offset: len(expr)}), nil
+}
+
+func isExprIdentRune(r rune) bool {
+	return r == '_' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	token := p.tokens[p.pos]
+	if token.kind != exprTokenEOF {
+		p.pos++
+	}
+	return token
+}
+
+// keyword consumes the next token if it's the keyword.
+func (p *exprParser) keyword(keyword string) bool {
+	if next := p.peek(); next.kind == exprTokenIdent && strings.EqualFold(next.value, keyword) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) errorf(token exprToken, format string, args ...interface{}) error {
+	return &ExprSyntaxError{Expr: p.expr, Offset: token.offset, Reason: fmt.Sprintf(format, args...)}
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = exprOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = exprAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+	if p.keyword("NOT") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return exprNot{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	token := p.next()
+	switch token.kind {
+	case exprTokenLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != exprTokenRParen {
+			return nil, p.errorf(closing, "expected \")\" to close the \"(\" at offset %d, found %s", token.offset, closing)
+		}
+		return node, nil
+	case exprTokenIdent:
+		predicate, ok := exprPredicates[token.value]
+		if !ok {
+			return nil, p.errorf(token, "unknown predicate %s, expected contains, sig or suite", token)
+		}
+		if open := p.next(); open.kind != exprTokenLParen {
+			return nil, p.errorf(open, "expected \"(\" after %s, found %s", token, open)
+		}
+		arg := p.next()
+		if arg.kind != exprTokenString {
+			return nil, p.errorf(arg, "expected a quoted string argument to %s, found %s", token, arg)
+		}
+		if closing := p.next(); closing.kind != exprTokenRParen {
+			return nil, p.errorf(closing, "expected \")\" after the argument to %s, found %s", token, closing)
+		}
+		return predicate(arg.value, p.caseInsensitive), nil
+	default:
+		return nil, p.errorf(token, "expected a predicate, NOT or \"(\", found %s", token)
+	}
+}
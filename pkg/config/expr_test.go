@@ -0,0 +1,121 @@
+package config
+
+import (
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestParseExpr(t *testing.T) {
+	test := &v1.TestInfo{
+		Name:  "[sig-network] OVN egress should work",
+		Suite: "openshift-tests",
+	}
+	tests := []struct {
+		name            string
+		expr            string
+		caseInsensitive bool
+		want            bool
+	}{
+		{name: "contains", expr: `contains("egress")`, want: true},
+		{name: "contains is case sensitive", expr: `contains("ovn")`, want: false},
+		{name: "contains with i/ prefix", expr: `contains("i/ovn")`, want: true},
+		{name: "contains with case insensitive matcher", expr: `contains("ovn")`, caseInsensitive: true, want: true},
+		{name: "sig", expr: `sig("sig-network")`, want: true},
+		{name: "suite", expr: `suite("openshift-tests")`, want: true},
+		{name: "and", expr: `sig("sig-network") AND contains("ingress")`, want: false},
+		{name: "or", expr: `sig("sig-storage") OR contains("egress")`, want: true},
+		{name: "not", expr: `NOT sig("sig-network")`, want: false},
+		{name: "double not", expr: `NOT NOT sig("sig-network")`, want: true},
+		{name: "and binds tighter than or", expr: `contains("egress") OR contains("ingress") AND sig("sig-storage")`, want: true},
+		{name: "parentheses", expr: `(contains("egress") OR contains("ingress")) AND sig("sig-storage")`, want: false},
+		{name: "lowercase keywords", expr: `sig("sig-network") and not contains("sdn")`, want: true},
+		{name: "escaped quote", expr: `contains("\"quoted\"")`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := parseExpr(tt.expr, tt.caseInsensitive)
+			if err != nil {
+				t.Fatalf("parseExpr() error = %v", err)
+			}
+			if got := node.eval(test); got != tt.want {
+				t.Errorf("eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExpr_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			expr:    ``,
+			wantErr: `expr "": syntax error at offset 0: expected a predicate, NOT or "(", found end of expression`,
+		},
+		{
+			name:    "unknown predicate",
+			expr:    `name("ovn")`,
+			wantErr: `expr "name(\"ovn\")": syntax error at offset 0: unknown predicate "name", expected contains, sig or suite`,
+		},
+		{
+			name:    "unquoted argument",
+			expr:    `contains(ovn)`,
+			wantErr: `expr "contains(ovn)": syntax error at offset 9: expected a quoted string argument to "contains", found "ovn"`,
+		},
+		{
+			name:    "unterminated string",
+			expr:    `contains("ovn)`,
+			wantErr: `expr "contains(\"ovn)": syntax error at offset 9: unterminated string`,
+		},
+		{
+			name:    "unclosed parenthesis",
+			expr:    `(sig("sig-network") OR contains("ovn")`,
+			wantErr: `expr "(sig(\"sig-network\") OR contains(\"ovn\")": syntax error at offset 38: expected ")" to close the "(" at offset 0, found end of expression`,
+		},
+		{
+			name:    "missing operator",
+			expr:    `sig("sig-network") contains("ovn")`,
+			wantErr: `expr "sig(\"sig-network\") contains(\"ovn\")": syntax error at offset 19: unexpected "contains"`,
+		},
+		{
+			name:    "dangling operator",
+			expr:    `sig("sig-network") AND`,
+			wantErr: `expr "sig(\"sig-network\") AND": syntax error at offset 22: expected a predicate, NOT or "(", found end of expression`,
+		},
+		{
+			name:    "unexpected character",
+			expr:    `sig("sig-network") && contains("ovn")`,
+			wantErr: `expr "sig(\"sig-network\") && contains(\"ovn\")": syntax error at offset 19: unexpected character '&'`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseExpr(tt.expr, false)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("parseExpr() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestComponent_CompileExpr(t *testing.T) {
+	c := &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking",
+		Matchers:             []ComponentMatcher{{Expr: `contains("ovn") OR`}},
+	}
+	want := `component "Networking" matcher 0: expr "contains(\"ovn\") OR": syntax error at offset 18: expected a predicate, NOT or "(", found end of expression`
+	if err := c.Compile(); err == nil || err.Error() != want {
+		t.Errorf("Compile() error = %v, want %s", err, want)
+	}
+
+	c.Matchers = []ComponentMatcher{{Expr: `contains("ovn")`, SIG: "sig-network"}}
+	want = `component "Networking" matcher 0: expr can't be combined with other conditions`
+	if err := c.Compile(); err == nil || err.Error() != want {
+		t.Errorf("Compile() error = %v, want %s", err, want)
+	}
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+	"github.com/openshift-eng/ci-test-mapping/pkg/util"
+)
+
+// fuzzComponent exercises every parser FindMatch uses on test names: tags, params, SIGs,
+// Jira fields, namespaces, hierarchy, substrings and alternations, words, globs, regexes
+// and numbers.
+func fuzzComponent(t testing.TB) *Component {
+	c := &Component{
+		Name:                 "Fuzz",
+		DefaultJiraComponent: "Fuzz",
+		Operators:            []string{"fuzz"},
+		Namespaces:           []string{"openshift-fuzz"},
+		NamespacePatterns:    []string{`in namespace "(?P<Namespace>[-\w]+)"`},
+		OwnsSIG:              []string{"sig-fuzz"},
+		NormalizeNames:       true,
+		TestRenames:          map[string]string{"[sig-fuzz] old": "[sig-fuzz] new"},
+		Matchers: []ComponentMatcher{
+			{
+				SIGAny:           []string{"sig-fuzz", "sig-other"},
+				Tags:             map[string][]string{"Feature": {"Fuzz"}},
+				Params:           map[string]string{"when": "upgrade"},
+				TopLevelContains: []string{"Fuzz"},
+				IncludeAll:       []string{"a|b", "i/C"},
+				IncludeWord:      []string{"fuzz"},
+				IncludeGlob:      []string{"*fuzz*"},
+				IncludeRegex:     []string{`(?P<phase>\w+) fuzz`},
+				Capabilities:     []string{"${phase}"},
+				MatchCleanName:   true,
+			},
+			{NameMatchesRegex: `.*\[Serial\].*`, Number: &NumberCondition{Pattern: `(?P<Number>\d+) attempts`, Threshold: ">= 3"}},
+			{SuiteIncludeAny: []string{"i/fuzz"}, NoSIG: true, Priority: PriorityFallback},
+		},
+	}
+	if err := c.Compile(); err != nil {
+		t.Fatalf("Compile() returned error: %v", err)
+	}
+	return c
+}
+
+func FuzzFindMatch(f *testing.F) {
+	for _, seed := range []struct{ name, suite string }{
+		{name: "[sig-fuzz][Feature:Fuzz][when=upgrade] Fuzz/a C fuzz [Serial]", suite: "fuzz"},
+		{name: `[Jira:"Fuzz"] should work in ns/openshift-fuzz`},
+		{name: `[Jira:"unterminated] [Feature: [=] [when= ] [sig-] ns/ namespace/ jira/"`},
+		{name: "[[[]]] ]][[ / // [Suite:a/b] \\ \x00 \xff", suite: "\xff"},
+		{name: "operator conditions fuzz 12345678901234567890123 attempts"},
+	} {
+		f.Add(seed.name, seed.suite)
+	}
+
+	c := fuzzComponent(f)
+	f.Fuzz(func(t *testing.T, name, suite string) {
+		test := &v1.TestInfo{Name: name, Suite: suite, Variants: []string{"Platform:aws"}}
+		c.FindMatchWithReason(test)
+		c.Explain(test)
+		Resolve([]*Component{c}, test)
+
+		util.ExtractTestTags(name)
+		util.ExtractTestParams(name)
+		util.ExtractSIG(name)
+		util.StripTags(name)
+		util.SplitTestHierarchy(name)
+		util.UnquoteField(name)
+		ExtractNamespacesFromTestName(name)
+		NormalizeTestName(name)
+	})
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GraphNodeKind identifies what a Graph node represents.
+type GraphNodeKind string
+
+const (
+	GraphNodeComponent GraphNodeKind = "component"
+	GraphNodeMatcher   GraphNodeKind = "matcher"
+	GraphNodeNamespace GraphNodeKind = "namespace"
+	GraphNodeSIG       GraphNodeKind = "sig"
+)
+
+// GraphEdgeKind identifies the relationship a Graph edge represents.
+type GraphEdgeKind string
+
+const (
+	// GraphEdgeMatcher links a component to one of its Matchers.
+	GraphEdgeMatcher GraphEdgeKind = "matcher"
+	// GraphEdgeParent links a component to its Parent.
+	GraphEdgeParent GraphEdgeKind = "parent"
+	// GraphEdgeOwnsNamespace links a component to one of its Namespaces.
+	GraphEdgeOwnsNamespace GraphEdgeKind = "owns-namespace"
+	// GraphEdgeOwnsSIG links a component to one of its OwnsSIG SIGs.
+	GraphEdgeOwnsSIG GraphEdgeKind = "owns-sig"
+	// GraphEdgeNamespaceOverride links a matcher to a namespace another component owns
+	// at a lower priority, so the matcher wins the namespace's tests it matches.
+	GraphEdgeNamespaceOverride GraphEdgeKind = "namespace-override"
+)
+
+// Graph is the components' configuration as nodes and edges, for visualizing ownership and
+// where conflicts are resolved, see ExportGraph. It serializes to JSON or YAML, and DOT
+// renders it for Graphviz.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes" yaml:"nodes"`
+	Edges []GraphEdge `json:"edges" yaml:"edges"`
+}
+
+// GraphNode is a component, matcher, namespace or SIG. IDs are prefixed by the kind, e.g.
+// "component/Networking" or "matcher/Networking/0", so they're unique across kinds.
+type GraphNode struct {
+	ID         string            `json:"id" yaml:"id"`
+	Kind       GraphNodeKind     `json:"kind" yaml:"kind"`
+	Label      string            `json:"label" yaml:"label"`
+	Attributes map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// GraphEdge is a relationship between two nodes, by ID. Ownership edges carry the priority
+// of the claims they represent in their "priority" attribute.
+type GraphEdge struct {
+	From       string            `json:"from" yaml:"from"`
+	To         string            `json:"to" yaml:"to"`
+	Kind       GraphEdgeKind     `json:"kind" yaml:"kind"`
+	Attributes map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// ExportGraph returns the components as a graph: a node for every component, each of its
+// matchers, and every namespace and OwnsSIG SIG any of them own, with edges for matchers,
+// parents and ownership. A matcher whose priority exceeds another component's namespace
+// priority gets a namespace-override edge to each of that component's namespaces, so
+// reviewers can see which namespace claims it's able to beat; whether it does depends on
+// the tests it matches. Parents are linked by name, so LinkParents needn't have been
+// called. Nodes are sorted by ID and edges by their ends and kind, so the output is stable.
+func ExportGraph(components []*Component) Graph {
+	nodes := make(map[string]GraphNode)
+	var edges []GraphEdge
+	addNode := func(node GraphNode) {
+		if _, ok := nodes[node.ID]; !ok {
+			nodes[node.ID] = node
+		}
+	}
+
+	known := make(map[string]bool, len(components))
+	for _, c := range components {
+		known[c.Name] = true
+	}
+
+	for _, c := range components {
+		componentID := graphComponentID(c.Name)
+		attributes := map[string]string{}
+		if c.DefaultJiraProject != "" {
+			attributes["jiraProject"] = c.DefaultJiraProject
+		}
+		if c.DefaultJiraComponent != "" {
+			attributes["jiraComponent"] = c.DefaultJiraComponent
+		}
+		addNode(GraphNode{ID: componentID, Kind: GraphNodeComponent, Label: c.Name, Attributes: attributes})
+
+		if c.Parent != "" && known[c.Parent] {
+			edges = append(edges, GraphEdge{From: componentID, To: graphComponentID(c.Parent), Kind: GraphEdgeParent})
+		}
+
+		for _, namespace := range c.Namespaces {
+			id := graphNamespaceID(namespace)
+			addNode(GraphNode{ID: id, Kind: GraphNodeNamespace, Label: namespace})
+			edges = append(edges, GraphEdge{From: componentID, To: id, Kind: GraphEdgeOwnsNamespace, Attributes: graphPriority(c.namespacePriority())})
+		}
+
+		for _, sig := range c.OwnsSIG {
+			id := graphSIGID(sig)
+			addNode(GraphNode{ID: id, Kind: GraphNodeSIG, Label: sig})
+			edges = append(edges, GraphEdge{From: componentID, To: id, Kind: GraphEdgeOwnsSIG, Attributes: graphPriority(c.OwnsSIGPriority)})
+		}
+
+		matchers := c.EffectiveMatchers()
+		for i := range matchers {
+			matcher := &matchers[i]
+			id := graphMatcherID(c.Name, i)
+			attributes := graphPriority(matcher.EffectivePriority())
+			attributes["specificity"] = strconv.Itoa(matcher.Specificity())
+			if matcher.JiraComponent != "" {
+				attributes["jiraComponent"] = matcher.JiraComponent
+			}
+			addNode(GraphNode{ID: id, Kind: GraphNodeMatcher, Label: fmt.Sprintf("%s matchers[%d]", c.Name, i), Attributes: attributes})
+			edges = append(edges, GraphEdge{From: componentID, To: id, Kind: GraphEdgeMatcher, Attributes: graphPriority(matcher.EffectivePriority())})
+
+			for _, owner := range components {
+				if owner == c || matcher.EffectivePriority() <= owner.namespacePriority() {
+					continue
+				}
+				for _, namespace := range owner.Namespaces {
+					edges = append(edges, GraphEdge{
+						From: id,
+						To:   graphNamespaceID(namespace),
+						Kind: GraphEdgeNamespaceOverride,
+						Attributes: map[string]string{
+							"priority":          strconv.Itoa(matcher.EffectivePriority()),
+							"owner":             owner.Name,
+							"namespacePriority": strconv.Itoa(owner.namespacePriority()),
+						},
+					})
+				}
+			}
+		}
+	}
+
+	graph := Graph{Nodes: make([]GraphNode, 0, len(nodes)), Edges: edges}
+	for _, node := range nodes {
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+	sort.SliceStable(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Kind < b.Kind
+	})
+	return graph
+}
+
+// DOT renders the graph in the Graphviz DOT language, with the node and edge attributes as
+// tooltips and edge labels showing priorities.
+func (g Graph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph components {\n")
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&sb, "\t%s [label=%s, shape=%s", strconv.Quote(node.ID), strconv.Quote(node.Label), graphShapes[node.Kind])
+		if len(node.Attributes) > 0 {
+			fmt.Fprintf(&sb, ", tooltip=%s", strconv.Quote(graphAttributes(node.Attributes)))
+		}
+		sb.WriteString("];\n")
+	}
+	for _, edge := range g.Edges {
+		label := string(edge.Kind)
+		if priority, ok := edge.Attributes["priority"]; ok {
+			label += " (" + priority + ")"
+		}
+		fmt.Fprintf(&sb, "\t%s -> %s [label=%s", strconv.Quote(edge.From), strconv.Quote(edge.To), strconv.Quote(label))
+		if edge.Kind == GraphEdgeNamespaceOverride {
+			sb.WriteString(", style=dashed, color=red")
+		}
+		if len(edge.Attributes) > 0 {
+			fmt.Fprintf(&sb, ", tooltip=%s", strconv.Quote(graphAttributes(edge.Attributes)))
+		}
+		sb.WriteString("];\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+var graphShapes = map[GraphNodeKind]string{
+	GraphNodeComponent: "box",
+	GraphNodeMatcher:   "ellipse",
+	GraphNodeNamespace: "folder",
+	GraphNodeSIG:       "hexagon",
+}
+
+// graphAttributes formats attributes as sorted key=value pairs.
+func graphAttributes(attributes map[string]string) string {
+	pairs := make([]string, 0, len(attributes))
+	for key, value := range attributes {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+func graphPriority(priority int) map[string]string {
+	return map[string]string{"priority": strconv.Itoa(priority)}
+}
+
+func graphComponentID(name string) string {
+	return string(GraphNodeComponent) + "/" + name
+}
+
+func graphMatcherID(component string, index int) string {
+	return fmt.Sprintf("%s/%s/%d", GraphNodeMatcher, component, index)
+}
+
+func graphNamespaceID(namespace string) string {
+	return string(GraphNodeNamespace) + "/" + namespace
+}
+
+func graphSIGID(sig string) string {
+	return string(GraphNodeSIG) + "/" + sig
+}
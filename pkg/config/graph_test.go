@@ -0,0 +1,72 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExportGraph(t *testing.T) {
+	networking := &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking",
+		Namespaces:           []string{"openshift-sdn"},
+		Matchers: []ComponentMatcher{
+			{SIG: "sig-network"},
+		},
+	}
+	router := &Component{
+		Name:       "Router",
+		Parent:     "Networking",
+		Namespaces: []string{"openshift-ingress"},
+		Matchers: []ComponentMatcher{
+			{IncludeAll: []string{"openshift-sdn", "route"}, Priority: PriorityOverride},
+		},
+	}
+	storage := &Component{
+		Name:            "Storage",
+		OwnsSIG:         []string{"sig-storage"},
+		OwnsSIGPriority: PriorityFallback,
+	}
+
+	graph := ExportGraph([]*Component{networking, router, storage})
+
+	wantNodes := []GraphNode{
+		{ID: "component/Networking", Kind: GraphNodeComponent, Label: "Networking", Attributes: map[string]string{"jiraComponent": "Networking"}},
+		{ID: "component/Router", Kind: GraphNodeComponent, Label: "Router", Attributes: map[string]string{}},
+		{ID: "component/Storage", Kind: GraphNodeComponent, Label: "Storage", Attributes: map[string]string{}},
+		{ID: "matcher/Networking/0", Kind: GraphNodeMatcher, Label: "Networking matchers[0]", Attributes: map[string]string{"priority": "0", "specificity": "1"}},
+		{ID: "matcher/Router/0", Kind: GraphNodeMatcher, Label: "Router matchers[0]", Attributes: map[string]string{"priority": "20", "specificity": "1"}},
+		{ID: "namespace/openshift-ingress", Kind: GraphNodeNamespace, Label: "openshift-ingress"},
+		{ID: "namespace/openshift-sdn", Kind: GraphNodeNamespace, Label: "openshift-sdn"},
+		{ID: "sig/sig-storage", Kind: GraphNodeSIG, Label: "sig-storage"},
+	}
+	if !reflect.DeepEqual(graph.Nodes, wantNodes) {
+		t.Errorf("nodes = %#v, want %#v", graph.Nodes, wantNodes)
+	}
+
+	wantEdges := []GraphEdge{
+		{From: "component/Networking", To: "matcher/Networking/0", Kind: GraphEdgeMatcher, Attributes: map[string]string{"priority": "0"}},
+		{From: "component/Networking", To: "namespace/openshift-sdn", Kind: GraphEdgeOwnsNamespace, Attributes: map[string]string{"priority": "10"}},
+		{From: "component/Router", To: "component/Networking", Kind: GraphEdgeParent},
+		{From: "component/Router", To: "matcher/Router/0", Kind: GraphEdgeMatcher, Attributes: map[string]string{"priority": "20"}},
+		{From: "component/Router", To: "namespace/openshift-ingress", Kind: GraphEdgeOwnsNamespace, Attributes: map[string]string{"priority": "10"}},
+		{From: "component/Storage", To: "sig/sig-storage", Kind: GraphEdgeOwnsSIG, Attributes: map[string]string{"priority": "-10"}},
+		{From: "matcher/Router/0", To: "namespace/openshift-sdn", Kind: GraphEdgeNamespaceOverride, Attributes: map[string]string{"priority": "20", "owner": "Networking", "namespacePriority": "10"}},
+	}
+	if !reflect.DeepEqual(graph.Edges, wantEdges) {
+		t.Errorf("edges = %#v, want %#v", graph.Edges, wantEdges)
+	}
+
+	dot := graph.DOT()
+	for _, want := range []string{
+		"digraph components {\n",
+		`"component/Networking" [label="Networking", shape=box, tooltip="jiraComponent=Networking"];`,
+		`"matcher/Router/0" -> "namespace/openshift-sdn" [label="namespace-override (20)", style=dashed, color=red, tooltip="namespacePriority=10, owner=Networking, priority=20"];`,
+		`"component/Router" -> "component/Networking" [label="parent"];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT() is missing %q:\n%s", want, dot)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// regexPrefix marks an ignore pattern as a regular expression, see SetIgnorePatterns.
+const regexPrefix = "re/"
+
+// ignorePattern is a compiled ignore pattern: a substring, or a regular expression.
+type ignorePattern struct {
+	substring
+	re *regexp.Regexp
+}
+
+var (
+	// ignorePatterns are the patterns of the tests no component may own, see
+	// SetIgnorePatterns. The slice is replaced rather than modified, so it's safe to use
+	// once read under ignorePatternsLock.
+	ignorePatterns     []ignorePattern
+	ignorePatternsLock sync.RWMutex
+)
+
+// SetIgnorePatterns replaces the patterns of the tests no component may own, such as setup,
+// teardown or framework scaffolding tests. A pattern is a substring of the test name, which
+// is case-insensitive with an i/ prefix like IncludeAny's, or a regular expression with an
+// re/ prefix, e.g. "re/^\[Setup\]". Resolve, and everything built on it, short-circuits for
+// an ignored test without asking any component's matchers, so no component claims it, see
+// IsIgnoredTest. An empty or nil list ignores nothing.
+//
+// If any pattern is empty or an invalid regular expression, the patterns are left
+// unchanged and an error describing each problem is returned. It's safe to call while
+// tests are being matched.
+func SetIgnorePatterns(patterns []string) error {
+	compiled := make([]ignorePattern, 0, len(patterns))
+	var errs ValidationErrors
+	for i, pattern := range patterns {
+		field := fmt.Sprintf("ignorePatterns[%d]", i)
+		switch {
+		case pattern == "" || pattern == regexPrefix || pattern == caseInsensitivePrefix:
+			errs = append(errs, &ConfigError{Field: field, Reason: "empty pattern"})
+		case strings.HasPrefix(pattern, regexPrefix):
+			re, err := regexp.Compile(strings.TrimPrefix(pattern, regexPrefix))
+			if err != nil {
+				errs = append(errs, &ConfigError{Field: field, Reason: err.Error()})
+				continue
+			}
+			compiled = append(compiled, ignorePattern{re: re})
+		default:
+			compiled = append(compiled, ignorePattern{substring: compileSubstrings([]string{pattern}, false)[0]})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	ignorePatternsLock.Lock()
+	defer ignorePatternsLock.Unlock()
+	ignorePatterns = compiled
+	return nil
+}
+
+// IsIgnoredTest reports whether the test's name matches one of the patterns set by
+// SetIgnorePatterns, so no component may own it.
+func IsIgnoredTest(test *v1.TestInfo) bool {
+	ignorePatternsLock.RLock()
+	patterns := ignorePatterns
+	ignorePatternsLock.RUnlock()
+	if len(patterns) == 0 {
+		return false
+	}
+
+	name := testName{name: test.Name, lower: strings.ToLower(test.Name)}
+	for _, pattern := range patterns {
+		if pattern.re != nil {
+			if pattern.re.MatchString(test.Name) {
+				return true
+			}
+		} else if pattern.in(name) {
+			return true
+		}
+	}
+	return false
+}
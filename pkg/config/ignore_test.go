@@ -0,0 +1,72 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestSetIgnorePatterns(t *testing.T) {
+	defer func() {
+		if err := SetIgnorePatterns(nil); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	var consulted []string
+	catchAll := &Component{
+		Name:       "CatchAll",
+		Namespaces: []string{"openshift-etcd"},
+		Matchers:   []ComponentMatcher{{IncludeNone: []string{"never"}}},
+		CustomMatchers: []func(*v1.TestInfo) *ComponentMatcher{
+			func(test *v1.TestInfo) *ComponentMatcher {
+				consulted = append(consulted, test.Name)
+				return nil
+			},
+		},
+	}
+	components := []*Component{catchAll}
+
+	if err := SetIgnorePatterns([]string{"[Setup]", "i/TEARDOWN", `re/^framework: .* scaffolding$`}); err != nil {
+		t.Fatalf("SetIgnorePatterns() returned error: %v", err)
+	}
+
+	ignored := []*v1.TestInfo{
+		{Name: "[Setup] install the cluster"},
+		{Name: "cluster teardown in ns/openshift-etcd"},
+		{Name: "framework: e2e scaffolding"},
+	}
+	for _, test := range ignored {
+		if !IsIgnoredTest(test) {
+			t.Errorf("IsIgnoredTest(%q) = false, want true", test.Name)
+		}
+		if owner, _ := Resolve(components, test); owner != nil {
+			t.Errorf("Resolve(%q) = %s, want no owner", test.Name, owner.Name)
+		}
+	}
+	if len(consulted) > 0 {
+		t.Errorf("ignored tests reached a custom matcher: %q", consulted)
+	}
+	if unmatched := UnmatchedTests(components, ignored); len(unmatched) > 0 {
+		t.Errorf("UnmatchedTests() = %d tests, want ignored tests excluded", len(unmatched))
+	}
+
+	test := &v1.TestInfo{Name: "framework: e2e scaffolding should work"}
+	if owner, _ := Resolve(components, test); owner != catchAll {
+		t.Errorf("Resolve(%q) = %v, want %s", test.Name, owner, catchAll.Name)
+	}
+
+	err := SetIgnorePatterns([]string{"[Setup]", "", "re/[Setup"})
+	if err == nil {
+		t.Fatalf("SetIgnorePatterns() returned no error for invalid patterns")
+	}
+	for _, want := range []string{"ignorePatterns[1]: empty pattern", "ignorePatterns[2]: error parsing regexp"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("SetIgnorePatterns() error = %v, want %q", err, want)
+		}
+	}
+	if !IsIgnoredTest(&v1.TestInfo{Name: "cluster teardown"}) {
+		t.Errorf("SetIgnorePatterns() changed the patterns despite returning an error")
+	}
+}
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// Lint looks for matchers that can never match, because their exclusions always fire
+// whenever their inclusions hold, e.g. the same substring in both IncludeAll and
+// ExcludeAny. Unlike Validate's errors, such matchers are harmless, but they're almost
+// certainly mistakes, so they're reported as warnings. Matchers with only exclusions,
+// which claim every test not excluded, are reported too: use IncludeNone to mean that.
+func (c *Component) Lint() []string {
+	var warnings []string
+	matchers := c.EffectiveMatchers()
+	for i := range matchers {
+		for _, warning := range matchers[i].lint() {
+			warnings = append(warnings, fmt.Sprintf("component %q matcher %d: %s", c.Name, i, warning))
+		}
+	}
+	return warnings
+}
+
+// LintPriorityCollisions looks for pairs of matchers, in the same or different components,
+// that share a priority and both match a test in the corpus. Within a component the first
+// such matcher silently wins, and across components the tie is only broken by specificity
+// and name, see Resolve, so such collisions are usually accidental. Each pair is reported
+// once, with the first test they both match; use the priority bands, e.g. PrioritySpecific,
+// to order them.
+func LintPriorityCollisions(components []*Component, tests []*v1.TestInfo) []string {
+	type matcherRef struct {
+		component *Component
+		index     int
+	}
+
+	var warnings []string
+	reported := make(map[[2]matcherRef]bool)
+	for _, test := range tests {
+		var matched []matcherRef
+		for _, c := range components {
+			matching := c.matchingTest(test)
+			matchers := c.EffectiveMatchers()
+			for i := range matchers {
+				if matchers[i].matches(matching, c.compiledMatcher(i)) {
+					matched = append(matched, matcherRef{component: c, index: i})
+				}
+			}
+		}
+
+		for i, a := range matched {
+			for _, b := range matched[i+1:] {
+				priority := a.component.EffectiveMatchers()[a.index].EffectivePriority()
+				if priority != b.component.EffectiveMatchers()[b.index].EffectivePriority() || reported[[2]matcherRef{a, b}] {
+					continue
+				}
+				reported[[2]matcherRef{a, b}] = true
+				warnings = append(warnings, fmt.Sprintf("component %q matcher %d and component %q matcher %d share priority %d, and both match %q",
+					a.component.Name, a.index, b.component.Name, b.index, priority, test.Name))
+			}
+		}
+	}
+	return warnings
+}
+
+func (cm *ComponentMatcher) lint() []string {
+	var warnings []string
+
+	for _, sig := range append([]string{cm.SIG}, cm.SIGAny...) {
+		for _, excluded := range cm.ExcludeSIG {
+			if sig != "" && sig == excluded {
+				warnings = append(warnings, fmt.Sprintf("sig %q is also in excludeSIG", sig))
+			}
+		}
+	}
+
+	if cm.NoSIG && (cm.SIG != "" || len(cm.SIGAny) > 0) {
+		warnings = append(warnings, "can never match, noSIG is set along with sig or sigAny")
+	}
+
+	for _, suite := range append([]string{cm.Suite}, cm.SuiteAny...) {
+		for _, excluded := range cm.ExcludeSuite {
+			if suite != "" && suite == excluded {
+				warnings = append(warnings, fmt.Sprintf("suite %q is also in excludeSuite", suite))
+			}
+		}
+	}
+
+	includeAll := compileAlternations(cm.IncludeAll, cm.CaseInsensitive)
+	includeAny := compileSubstrings(cm.IncludeAny, cm.CaseInsensitive)
+	includeNone := compileSubstrings(cm.IncludeNone, cm.CaseInsensitive)
+	excludeAll := compileSubstrings(cm.ExcludeAll, cm.CaseInsensitive)
+	excludeAny := compileSubstrings(cm.ExcludeAny, cm.CaseInsensitive)
+
+	for i, include := range includeAll {
+		for j, exclude := range excludeAny {
+			if alternativesImply(include, exclude) {
+				warnings = append(warnings, fmt.Sprintf("can never match, includeAll %q always matches excludeAny %q", cm.IncludeAll[i], cm.ExcludeAny[j]))
+			}
+		}
+	}
+
+	for i, include := range includeAny {
+		for j, exclude := range excludeAny {
+			if include.implies(exclude) {
+				warnings = append(warnings, fmt.Sprintf("includeAny %q can never match, it always matches excludeAny %q", cm.IncludeAny[i], cm.ExcludeAny[j]))
+			}
+		}
+	}
+
+	for i, include := range includeAll {
+		for j, none := range includeNone {
+			if alternativesImply(include, none) {
+				warnings = append(warnings, fmt.Sprintf("can never match, includeAll %q always matches includeNone %q", cm.IncludeAll[i], cm.IncludeNone[j]))
+			}
+		}
+	}
+
+	for i, include := range includeAny {
+		for j, none := range includeNone {
+			if include.implies(none) {
+				warnings = append(warnings, fmt.Sprintf("includeAny %q can never match, it always matches includeNone %q", cm.IncludeAny[i], cm.IncludeNone[j]))
+			}
+		}
+	}
+
+	if len(excludeAll) > 0 && allImplied(includeAll, excludeAll) {
+		warnings = append(warnings, fmt.Sprintf("can never match, includeAll %q always matches every excludeAll %q", cm.IncludeAll, cm.ExcludeAll))
+	}
+
+	if !cm.Negate && cm.onlyExclusions() {
+		warnings = append(warnings, "only has exclusions, so matches every other test; use includeNone if that's intended")
+	}
+
+	return warnings
+}
+
+// onlyExclusions reports whether the matcher's only conditions are exclusions.
+func (cm *ComponentMatcher) onlyExclusions() bool {
+	exclusions := 0
+	for _, set := range []bool{len(cm.ExcludeSIG) > 0, len(cm.ExcludeSuite) > 0, len(cm.ExcludeAll) > 0, len(cm.ExcludeAny) > 0, len(cm.ExcludeWord) > 0, len(cm.ExcludeGlob) > 0} {
+		if set {
+			exclusions++
+		}
+	}
+	return exclusions > 0 && exclusions == cm.Specificity()
+}
+
+// implies reports whether a test name containing s always contains other.
+func (s substring) implies(other substring) bool {
+	if other.caseInsensitive {
+		return strings.Contains(strings.ToLower(s.value), other.value)
+	}
+	return !s.caseInsensitive && strings.Contains(s.value, other.value)
+}
+
+// alternativesImply reports whether a test name containing any of the alternatives always
+// contains other.
+func alternativesImply(alternatives []substring, other substring) bool {
+	for _, alternative := range alternatives {
+		if !alternative.implies(other) {
+			return false
+		}
+	}
+	return true
+}
+
+// allImplied reports whether a test name containing one of the alternatives of each of the
+// includes always contains every substring in excludes.
+func allImplied(includes [][]substring, excludes []substring) bool {
+	for _, exclude := range excludes {
+		implied := false
+		for _, include := range includes {
+			if alternativesImply(include, exclude) {
+				implied = true
+				break
+			}
+		}
+		if !implied {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestComponent_Lint(t *testing.T) {
+	tests := []struct {
+		name         string
+		matcher      ComponentMatcher
+		wantWarnings []string
+	}{
+		{
+			name: "satisfiable matcher",
+			matcher: ComponentMatcher{
+				SIG:        "sig-network",
+				IncludeAll: []string{"ovn"},
+				ExcludeAny: []string{"sdn"},
+				ExcludeAll: []string{"ovn", "legacy"},
+			},
+		},
+		{
+			name: "include all substring is also excluded",
+			matcher: ComponentMatcher{
+				IncludeAll: []string{"ovn-kubernetes"},
+				ExcludeAny: []string{"ovn"},
+			},
+			wantWarnings: []string{
+				`component "Networking" matcher 0: can never match, includeAll "ovn-kubernetes" always matches excludeAny "ovn"`,
+			},
+		},
+		{
+			name: "include all alternation is only excluded if every alternative is",
+			matcher: ComponentMatcher{
+				IncludeAll: []string{"ovn-kubernetes|ovnkube", "sdn|ovn-ic"},
+				ExcludeAny: []string{"ovn"},
+			},
+			wantWarnings: []string{
+				`component "Networking" matcher 0: can never match, includeAll "ovn-kubernetes|ovnkube" always matches excludeAny "ovn"`,
+			},
+		},
+		{
+			name: "include any substring is also excluded",
+			matcher: ComponentMatcher{
+				IncludeAny: []string{"sdn", "ovn"},
+				ExcludeAny: []string{"i/OVN"},
+			},
+			wantWarnings: []string{
+				`component "Networking" matcher 0: includeAny "ovn" can never match, it always matches excludeAny "i/OVN"`,
+			},
+		},
+		{
+			name: "case-insensitive include doesn't imply exact exclude",
+			matcher: ComponentMatcher{
+				IncludeAll: []string{"i/ovn"},
+				ExcludeAny: []string{"ovn"},
+			},
+		},
+		{
+			name: "exclude all is required by include all",
+			matcher: ComponentMatcher{
+				IncludeAll: []string{"ovn", "egress ip"},
+				ExcludeAll: []string{"egress", "ovn"},
+			},
+			wantWarnings: []string{
+				`component "Networking" matcher 0: can never match, includeAll ["ovn" "egress ip"] always matches every excludeAll ["egress" "ovn"]`,
+			},
+		},
+		{
+			name: "no sig with a sig",
+			matcher: ComponentMatcher{
+				SIG:   "sig-network",
+				NoSIG: true,
+			},
+			wantWarnings: []string{
+				`component "Networking" matcher 0: can never match, noSIG is set along with sig or sigAny`,
+			},
+		},
+		{
+			name: "sig is also excluded",
+			matcher: ComponentMatcher{
+				SIGAny:     []string{"sig-network", "sig-network-edge"},
+				ExcludeSIG: []string{"sig-network-edge"},
+			},
+			wantWarnings: []string{
+				`component "Networking" matcher 0: sig "sig-network-edge" is also in excludeSIG`,
+			},
+		},
+		{
+			name: "suite is also excluded",
+			matcher: ComponentMatcher{
+				Suite:        "openshift/conformance/parallel",
+				ExcludeSuite: []string{"openshift/conformance/parallel"},
+			},
+			wantWarnings: []string{
+				`component "Networking" matcher 0: suite "openshift/conformance/parallel" is also in excludeSuite`,
+			},
+		},
+		{
+			name: "include substring is in include none",
+			matcher: ComponentMatcher{
+				IncludeAll:  []string{"ovn-kubernetes"},
+				IncludeAny:  []string{"egress", "SDN"},
+				IncludeNone: []string{"ovn", "i/sdn"},
+			},
+			wantWarnings: []string{
+				`component "Networking" matcher 0: can never match, includeAll "ovn-kubernetes" always matches includeNone "ovn"`,
+				`component "Networking" matcher 0: includeAny "SDN" can never match, it always matches includeNone "i/sdn"`,
+			},
+		},
+		{
+			name: "only exclusions",
+			matcher: ComponentMatcher{
+				ExcludeAny: []string{"sdn"},
+			},
+			wantWarnings: []string{
+				`component "Networking" matcher 0: only has exclusions, so matches every other test; use includeNone if that's intended`,
+			},
+		},
+		{
+			name: "only include none",
+			matcher: ComponentMatcher{
+				IncludeNone: []string{"sdn"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Component{
+				Name:     "Networking",
+				Matchers: []ComponentMatcher{tt.matcher},
+			}
+			if got := c.Lint(); !reflect.DeepEqual(got, tt.wantWarnings) {
+				t.Errorf("Lint() = %q, want %q", got, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestLintPriorityCollisions(t *testing.T) {
+	networking := &Component{
+		Name: "Networking",
+		Matchers: []ComponentMatcher{
+			{SIG: "sig-network"},
+			{IncludeAll: []string{"ovn"}},
+			{IncludeAll: []string{"router"}, Priority: PrioritySpecific},
+		},
+	}
+	router := &Component{
+		Name: "Router",
+		Matchers: []ComponentMatcher{
+			{IncludeAll: []string{"router"}, Priority: PrioritySpecific},
+			{IncludeAll: []string{"ingress"}, Priority: PriorityOverride},
+		},
+	}
+	tests := []*v1.TestInfo{
+		{Name: "[sig-network] ovn pods should be ready"},
+		{Name: "[sig-network] ovn pods should be ready again"},
+		{Name: "[sig-network-edge] router should route"},
+		{Name: "[sig-network] ingress should be admitted"},
+	}
+
+	want := []string{
+		`component "Networking" matcher 0 and component "Networking" matcher 1 share priority 0, and both match "[sig-network] ovn pods should be ready"`,
+		`component "Networking" matcher 2 and component "Router" matcher 0 share priority 5, and both match "[sig-network-edge] router should route"`,
+	}
+	if got := LintPriorityCollisions([]*Component{networking, router}, tests); !reflect.DeepEqual(got, want) {
+		t.Errorf("LintPriorityCollisions() =\n%q\nwant\n%q", got, want)
+	}
+}
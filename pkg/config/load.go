@@ -0,0 +1,134 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// yamlLineRegex extracts the line number from a yaml error message, e.g.
+// "line 3: field foo not found".
+var yamlLineRegex = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// LoadComponentFile reads a component's YAML configuration from path, validates and compiles
+// it. Any error is returned as a ValidationErrors of *ConfigError, each identifying the file,
+// and where known the line, component and field, of a problem.
+func LoadComponentFile(path string) (*Component, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, ValidationErrors{&ConfigError{Path: path, Reason: err.Error()}}
+	}
+
+	c := &Component{}
+	if err := yaml.UnmarshalStrict(data, c); err != nil {
+		return nil, yamlConfigErrors(path, c.Name, err)
+	}
+
+	if err := c.Validate(); err != nil {
+		var errs ValidationErrors
+		if errors.As(err, &errs) {
+			for _, err := range errs {
+				var configErr *ConfigError
+				if errors.As(err, &configErr) {
+					configErr.Path = path
+				}
+			}
+		}
+		return nil, err
+	}
+
+	if err := c.Compile(); err != nil {
+		return nil, ValidationErrors{&ConfigError{Path: path, Component: c.Name, Reason: err.Error()}}
+	}
+
+	return c, nil
+}
+
+// LoadComponentsFromDir loads every .yaml file under root, recursively and in lexical
+// order, as a component with LoadComponentFile, then links their parents with
+// LinkParents. Problems with every file are collected rather than stopping at the first,
+// and returned as a ValidationErrors of *ConfigError identifying the file of each. Two
+// files defining components with the same name is an error too. Checks across the
+// components, other than their names and parents, are left to ValidateAll.
+func LoadComponentsFromDir(root string) ([]*Component, error) {
+	var errs ValidationErrors
+	var components []*Component
+	paths := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			errs = append(errs, &ConfigError{Path: path, Reason: err.Error()})
+			if entry != nil && entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".yaml" {
+			return nil
+		}
+
+		c, err := LoadComponentFile(path)
+		if err != nil {
+			var fileErrs ValidationErrors
+			if errors.As(err, &fileErrs) {
+				errs = append(errs, fileErrs...)
+			} else {
+				errs = append(errs, &ConfigError{Path: path, Reason: err.Error()})
+			}
+			return nil
+		}
+		if first, ok := paths[c.Name]; ok {
+			errs = append(errs, &ConfigError{Path: path, Component: c.Name, Reason: fmt.Sprintf("duplicate component name, also defined in %s", first)})
+			return nil
+		}
+		paths[c.Name] = path
+		components = append(components, c)
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, &ConfigError{Path: root, Reason: err.Error()})
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if err := LinkParents(components); err != nil {
+		var linkErrs ValidationErrors
+		if errors.As(err, &linkErrs) {
+			for _, err := range linkErrs {
+				var configErr *ConfigError
+				if errors.As(err, &configErr) {
+					configErr.Path = paths[configErr.Component]
+				}
+			}
+		}
+		return nil, err
+	}
+	return components, nil
+}
+
+// yamlConfigErrors converts a yaml unmarshal error into a ConfigError per problem reported.
+func yamlConfigErrors(path, component string, err error) ValidationErrors {
+	msgs := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		msgs = typeErr.Errors
+	}
+
+	errs := make(ValidationErrors, 0, len(msgs))
+	for _, msg := range msgs {
+		configErr := &ConfigError{Path: path, Component: component, Reason: strings.TrimPrefix(msg, "yaml: ")}
+		if m := yamlLineRegex.FindStringSubmatch(msg); m != nil {
+			configErr.Line, _ = strconv.Atoi(m[1])
+			configErr.Reason = m[2]
+		}
+		errs = append(errs, configErr)
+	}
+	return errs
+}
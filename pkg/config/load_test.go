@@ -0,0 +1,256 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestLoadComponentFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []ConfigError
+		matches string
+	}{
+		{
+			name: "valid component",
+			data: `name: etcd
+defaultJiraComponent: Etcd
+matchers:
+- includeAll: ["[sig-etcd]"]
+`,
+			matches: "[sig-etcd] should be healthy",
+		},
+		{
+			name: "unknown field",
+			data: `name: etcd
+defaultJiraComponent: Etcd
+matcher:
+- includeAll: ["[sig-etcd]"]
+`,
+			want: []ConfigError{
+				{Line: 3, Component: "etcd", Reason: "field matcher not found in type config.Component"},
+			},
+		},
+		{
+			name: "wrong type",
+			data: `name: etcd
+variants: Platform:aws
+namespaces: openshift-etcd
+`,
+			want: []ConfigError{
+				{Line: 2, Component: "etcd", Reason: "cannot unmarshal !!str `Platfor...` into []string"},
+				{Line: 3, Component: "etcd", Reason: "cannot unmarshal !!str `openshi...` into []string"},
+			},
+		},
+		{
+			name: "syntax error",
+			data: "name: etcd\nmatchers: [\n",
+			want: []ConfigError{
+				{Line: 2, Reason: "did not find expected node content"},
+			},
+		},
+		{
+			name: "invalid config",
+			data: `name: etcd
+matchers:
+- includeAll: ["[sig-etcd]"]
+  variants: ["aws"]
+`,
+			want: []ConfigError{
+				{Component: "etcd", Field: "matchers[0].jiraComponent", Reason: "no jira component, and the component has no default jira component"},
+				{Component: "etcd", Field: "matchers[0].variants[0]", Reason: `variant "aws" is not formatted as variantCategory:variantValue`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "component.yaml")
+			if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			c, err := LoadComponentFile(path)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("LoadComponentFile() returned error: %v", err)
+				}
+				if m := c.FindMatch(&v1.TestInfo{Name: tt.matches}); m == nil {
+					t.Errorf("FindMatch(%q) = nil, want a match", tt.matches)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("LoadComponentFile() error = %v, want ValidationErrors", err)
+			}
+			if len(errs) != len(tt.want) {
+				t.Fatalf("LoadComponentFile() returned %d errors (%v), want %d", len(errs), err, len(tt.want))
+			}
+			for i, err := range errs {
+				var got *ConfigError
+				if !errors.As(err, &got) {
+					t.Fatalf("error %d = %v, want *ConfigError", i, err)
+				}
+				want := tt.want[i]
+				want.Path = path
+				if *got != want {
+					t.Errorf("error %d = %+v, want %+v", i, *got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadComponentFileMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.yaml")
+	_, err := LoadComponentFile(path)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].(*ConfigError).Path != path {
+		t.Errorf("LoadComponentFile() error = %v, want a ConfigError for %s", err, path)
+	}
+}
+
+// writeComponentFiles writes the files, by path relative to the returned directory.
+func writeComponentFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, data := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestLoadComponentsFromDir(t *testing.T) {
+	root := writeComponentFiles(t, map[string]string{
+		"storage/storage.yaml": `name: Storage
+defaultJiraComponent: Storage
+ownsSIG: ["sig-storage"]
+ownsSIGPriority: -10
+`,
+		"storage/csi/csi.yaml": `name: CSI
+parent: Storage
+defaultJiraComponent: Storage / CSI
+matchers:
+- includeAll: ["CSI"]
+`,
+		"etcd.yaml": `name: Etcd
+defaultJiraComponent: Etcd
+matchers:
+- includeAll: ["[sig-etcd]"]
+`,
+		"README.md": "not a component",
+	})
+
+	components, err := LoadComponentsFromDir(root)
+	if err != nil {
+		t.Fatalf("LoadComponentsFromDir() error = %v", err)
+	}
+	var names []string
+	for _, c := range components {
+		names = append(names, c.Name)
+	}
+	if want := []string{"Etcd", "CSI", "Storage"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("LoadComponentsFromDir() = %q, want %q", names, want)
+	}
+
+	// The parent is linked, so Storage's fallback defers to CSI.
+	owner, _ := Resolve(components, &v1.TestInfo{Name: "[sig-storage] CSI volumes should mount"})
+	if owner == nil || owner.Name != "CSI" {
+		t.Errorf("Resolve() = %v, want CSI", owner)
+	}
+}
+
+func TestLoadComponentsFromDirErrors(t *testing.T) {
+	root := writeComponentFiles(t, map[string]string{
+		"a/etcd.yaml": `name: Etcd
+defaultJiraComponent: Etcd
+`,
+		"b/etcd.yaml": `name: Etcd
+defaultJiraComponent: Etcd / backup
+`,
+		"c/invalid.yaml": `name: Storage
+defaultJiraComponent: Storage
+variants: ["aws"]
+`,
+	})
+
+	_, err := LoadComponentsFromDir(root)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("LoadComponentsFromDir() error = %v, want ValidationErrors", err)
+	}
+	want := []ConfigError{
+		{Path: filepath.Join(root, "b/etcd.yaml"), Component: "Etcd", Reason: "duplicate component name, also defined in " + filepath.Join(root, "a/etcd.yaml")},
+		{Path: filepath.Join(root, "c/invalid.yaml"), Component: "Storage", Field: "variants[0]", Reason: `variant "aws" is not formatted as variantCategory:variantValue`},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("LoadComponentsFromDir() errors = %v, want %d", errs, len(want))
+	}
+	for i, err := range errs {
+		var got *ConfigError
+		if !errors.As(err, &got) || *got != want[i] {
+			t.Errorf("error %d = %v, want %+v", i, err, want[i])
+		}
+	}
+}
+
+func TestLoadComponentsFromDirUnknownParent(t *testing.T) {
+	root := writeComponentFiles(t, map[string]string{
+		"csi.yaml": `name: CSI
+parent: Storage
+defaultJiraComponent: Storage / CSI
+`,
+	})
+
+	_, err := LoadComponentsFromDir(root)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("LoadComponentsFromDir() error = %v, want one error", err)
+	}
+	want := ConfigError{Path: filepath.Join(root, "csi.yaml"), Component: "CSI", Field: "parent", Reason: `no component named "Storage"`}
+	if got := errs[0].(*ConfigError); *got != want {
+		t.Errorf("error = %+v, want %+v", *got, want)
+	}
+}
+
+func TestConfigError_Error(t *testing.T) {
+	tests := []struct {
+		err  ConfigError
+		want string
+	}{
+		{
+			err:  ConfigError{Path: "etcd.yaml", Line: 3, Component: "etcd", Field: "matchers[0]", Reason: "bad"},
+			want: `etcd.yaml:3: component "etcd" matchers[0]: bad`,
+		},
+		{
+			err:  ConfigError{Path: "etcd.yaml", Reason: "bad"},
+			want: "etcd.yaml: bad",
+		},
+		{
+			err:  ConfigError{Component: "etcd", Reason: "bad"},
+			want: `component "etcd": bad`,
+		},
+		{
+			err:  ConfigError{Reason: "bad"},
+			want: "bad",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
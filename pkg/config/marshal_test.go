@@ -0,0 +1,232 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+// representativeComponent sets every serialized field of Component and ComponentMatcher.
+func representativeComponent() Component {
+	serial := true
+	return Component{
+		Name:                  "Networking",
+		Parent:                "Core",
+		DefaultJiraProject:    "OCPBUGS",
+		DefaultJiraComponent:  "Networking",
+		DefaultCapabilities:   []string{"Networking"},
+		ExcludeJiraComponents: []string{"Networking / router"},
+		Aliases:               []string{"Networking / cluster-network-operator"},
+		Operators:             []string{"network"},
+		OperatorConfigs: []OperatorConfig{
+			{Name: "ovn-kubernetes", Capabilities: []string{"OVN"}},
+		},
+		OperatorTestPatterns: map[string][]string{
+			"network": {`network operator should (?P<Capability>install)`},
+		},
+		Namespaces:         []string{"openshift-multus", "openshift-network-operator"},
+		OwnsSIG:            []string{"sig-network"},
+		NamespacePriority:  20,
+		OwnsSIGPriority:    1,
+		NamespacePatterns:  []string{`in namespace "(?P<Namespace>[-\w]+)"`},
+		JiraComponentRegex: `\(owner: (?P<Component>[^)]+)\)`,
+		Variants:           []string{"network:ovn"},
+		NormalizeNames:     true,
+		SortMatchers:       true,
+		TestRenames: map[string]string{
+			"[sig-network] pods should have networking": "[sig-network] pods should network",
+		},
+		TestRenameRules: []RenameRule{
+			{Pattern: `ovn (\d+)\.(\d+)`, Replacement: "ovn"},
+		},
+		Matchers: []ComponentMatcher{
+			{
+				SIG:              "sig-network",
+				SIGAny:           []string{"sig-network-edge"},
+				ExcludeSIG:       []string{"sig-storage"},
+				Suite:            "openshift/conformance/parallel",
+				SuiteAny:         []string{"openshift/conformance/serial"},
+				SuitePrefix:      "openshift/conformance/",
+				SuiteIncludeAll:  []string{"conformance"},
+				SuiteIncludeAny:  []string{"parallel", "serial"},
+				ExcludeSuite:     []string{"openshift/disruptive"},
+				Variants:         []string{"platform:aws"},
+				Repo:             "openshift/origin",
+				RepoAny:          []string{"openshift/origin", "openshift/ovn-kubernetes"},
+				RequireFlags:     []string{"Serial"},
+				Annotations:      []string{AnnotationLongName},
+				IsDisruption:     true,
+				Serial:           &serial,
+				NoSIG:            true,
+				NamePrefix:       "[sig-network]",
+				NameSuffix:       "[Serial]",
+				Tags:             map[string][]string{"Feature": {"EgressIP"}},
+				Params:           map[string]string{"when": "upgrade"},
+				TopLevelContains: []string{"TestOVN"},
+				IncludeAll:       []string{"ovn"},
+				IncludeAny:       []string{"egress", "ingress"},
+				IncludeNone:      []string{"sdn"},
+				ExcludeAll:       []string{"sdn", "legacy"},
+				ExcludeAny:       []string{"flaky"},
+				IncludeWord:      []string{"ovn"},
+				ExcludeWord:      []string{"sdn"},
+				IncludeGlob:      []string{"*ovn*"},
+				PathGlob:         []string{"test/extended/networking/**"},
+				ExcludeGlob:      []string{"*sdn*"},
+				IncludeRegex:     []string{`ovn-(\w+)`},
+				NameMatchesRegex: `\[sig-network\].*`,
+				Number:           &NumberCondition{Pattern: `(?P<Number>\d+) pods`, Threshold: ">= 50"},
+				Expr:             `contains("ovn") AND NOT sig("sig-storage")`,
+				Negate:           true,
+				MatchCleanName:   true,
+				CaseInsensitive:  true,
+				JiraProject:      "CORENET",
+				JiraComponent:    "Networking / ovn-kubernetes",
+				Capabilities:     []string{"EgressIP"},
+				Priority:         2,
+				TemplateRef:      "networking",
+				EffectiveFrom:    "4.12",
+				EffectiveUntil:   "4.16",
+			},
+		},
+	}
+}
+
+func TestComponent_MarshalRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		marshal   func(interface{}) ([]byte, error)
+		unmarshal func([]byte, interface{}) error
+	}{
+		{
+			name:      "json",
+			marshal:   json.Marshal,
+			unmarshal: json.Unmarshal,
+		},
+		{
+			name:      "yaml",
+			marshal:   yaml.Marshal,
+			unmarshal: yaml.Unmarshal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := representativeComponent()
+			data, err := tt.marshal(want)
+			if err != nil {
+				t.Fatalf("marshal returned error: %v", err)
+			}
+
+			var got Component
+			if err := tt.unmarshal(data, &got); err != nil {
+				t.Fatalf("unmarshal returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestComponent_UnmarshalYAML(t *testing.T) {
+	data := `
+name: Etcd
+defaultJiraProject: OCPBUGS
+defaultJiraComponent: Etcd
+operators:
+- etcd
+namespaces:
+- openshift-etcd
+matchers:
+- sig: sig-etcd
+- includeAny:
+  - ':Etcd '
+  priority: 1
+- suiteAny:
+  - DR_Testing
+  - ETCD
+  capabilities:
+  - DR
+testRenames:
+  '[bz-etcd] old name': '[bz-etcd] new name'
+`
+	want := Component{
+		Name:                 "Etcd",
+		DefaultJiraProject:   "OCPBUGS",
+		DefaultJiraComponent: "Etcd",
+		Operators:            []string{"etcd"},
+		Namespaces:           []string{"openshift-etcd"},
+		Matchers: []ComponentMatcher{
+			{SIG: "sig-etcd"},
+			{IncludeAny: []string{":Etcd "}, Priority: 1},
+			{SuiteAny: []string{"DR_Testing", "ETCD"}, Capabilities: []string{"DR"}},
+		},
+		TestRenames: map[string]string{
+			"[bz-etcd] old name": "[bz-etcd] new name",
+		},
+	}
+
+	var got Component
+	if err := yaml.UnmarshalStrict([]byte(data), &got); err != nil {
+		t.Fatalf("UnmarshalStrict() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnmarshalStrict() = %+v, want %+v", got, want)
+	}
+}
+
+func TestOperatorConfig_Unmarshal(t *testing.T) {
+	want := []OperatorConfig{
+		{Name: "network"},
+		{Name: "ovn-kubernetes", Capabilities: []string{"OVN"}},
+	}
+
+	var fromJSON []OperatorConfig
+	if err := json.Unmarshal([]byte(`["network", {"name": "ovn-kubernetes", "capabilities": ["OVN"]}]`), &fromJSON); err != nil {
+		t.Fatalf("json.Unmarshal() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(fromJSON, want) {
+		t.Errorf("json.Unmarshal() = %+v, want %+v", fromJSON, want)
+	}
+
+	var fromYAML []OperatorConfig
+	data := `
+- network
+- name: ovn-kubernetes
+  capabilities:
+  - OVN
+`
+	if err := yaml.UnmarshalStrict([]byte(data), &fromYAML); err != nil {
+		t.Fatalf("yaml.UnmarshalStrict() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(fromYAML, want) {
+		t.Errorf("yaml.UnmarshalStrict() = %+v, want %+v", fromYAML, want)
+	}
+}
+
+// TestSerializedFieldTags makes sure new exported fields get explicit tags, so the
+// serialized schema doesn't depend on Go field names.
+func TestSerializedFieldTags(t *testing.T) {
+	for _, typ := range []reflect.Type{
+		reflect.TypeOf(Component{}),
+		reflect.TypeOf(ComponentMatcher{}),
+		reflect.TypeOf(RenameRule{}),
+		reflect.TypeOf(OperatorConfig{}),
+		reflect.TypeOf(NumberCondition{}),
+	} {
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			jsonTag, yamlTag := field.Tag.Get("json"), field.Tag.Get("yaml")
+			if jsonTag == "" || yamlTag == "" {
+				t.Errorf("%s.%s is missing a json or yaml tag", typ.Name(), field.Name)
+			} else if jsonTag != yamlTag {
+				t.Errorf("%s.%s json tag %q doesn't match yaml tag %q", typ.Name(), field.Name, jsonTag, yamlTag)
+			}
+		}
+	}
+}
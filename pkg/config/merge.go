@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Merge returns a new component owning everything c and other own, for folding one
+// component's ownership into another. The merged component has c's name, and c's Jira
+// defaults unless they're unset, in which case other's are used. Other's matchers
+// without a Jira project or component are given other's defaults, so its tests keep
+// their Jira project and component; tests other only claimed by their Jira field, see
+// FindMatch, aren't carried over. Likewise, other's DefaultCapabilities are added to its
+// matchers' Capabilities, and the merged component has c's DefaultCapabilities, Aliases,
+// ExcludeJiraComponents and JiraComponentRegex. The merged component sorts its matchers
+// if either does, see SortMatchers.
+//
+// The components are left unchanged. If they conflict, e.g. by renaming the same test
+// to different names, Merge returns a ValidationErrors listing every conflict.
+func (c *Component) Merge(other *Component) (*Component, error) {
+	var errs ValidationErrors
+	conflict := func(field, format string, args ...interface{}) {
+		errs = append(errs, &ConfigError{
+			Component: c.Name,
+			Field:     field,
+			Reason:    fmt.Sprintf("conflicts with component %q: ", other.Name) + fmt.Sprintf(format, args...),
+		})
+	}
+
+	merged := &Component{
+		Name:                  c.Name,
+		Parent:                c.Parent,
+		DefaultJiraProject:    c.DefaultJiraProject,
+		DefaultJiraComponent:  c.DefaultJiraComponent,
+		DefaultCapabilities:   unionStrings(c.DefaultCapabilities, nil),
+		ExcludeJiraComponents: unionStrings(c.ExcludeJiraComponents, nil),
+		Aliases:               unionStrings(c.Aliases, nil),
+		Operators:             unionStrings(c.Operators, other.Operators),
+		OperatorConfigs:       append(c.OperatorConfigs[:len(c.OperatorConfigs):len(c.OperatorConfigs)], other.OperatorConfigs...),
+		Namespaces:            unionStrings(c.Namespaces, other.Namespaces),
+		NamespacePriority:     c.NamespacePriority,
+		OwnsSIG:               unionStrings(c.OwnsSIG, other.OwnsSIG),
+		OwnsSIGPriority:       c.OwnsSIGPriority,
+		NamespacePatterns:     unionStrings(c.NamespacePatterns, other.NamespacePatterns),
+		JiraComponentRegex:    c.JiraComponentRegex,
+		Variants:              unionStrings(c.Variants, other.Variants),
+		NormalizeNames:        c.NormalizeNames,
+		SortMatchers:          c.SortMatchers || other.SortMatchers,
+		TestRenameRules:       append(c.TestRenameRules[:len(c.TestRenameRules):len(c.TestRenameRules)], other.TestRenameRules...),
+		CustomMatchers:        append(c.CustomMatchers[:len(c.CustomMatchers):len(c.CustomMatchers)], other.CustomMatchers...),
+	}
+	if merged.DefaultJiraProject == "" {
+		merged.DefaultJiraProject = other.DefaultJiraProject
+	}
+	if merged.DefaultJiraComponent == "" {
+		merged.DefaultJiraComponent = other.DefaultJiraComponent
+	}
+
+	if len(c.OwnsSIG) == 0 {
+		merged.OwnsSIGPriority = other.OwnsSIGPriority
+	} else if len(other.OwnsSIG) > 0 && c.OwnsSIGPriority != other.OwnsSIGPriority {
+		conflict("ownsSIGPriority", "priority %d, and %d", c.OwnsSIGPriority, other.OwnsSIGPriority)
+	}
+
+	if len(c.Namespaces) == 0 {
+		merged.NamespacePriority = other.NamespacePriority
+	} else if len(other.Namespaces) > 0 && c.namespacePriority() != other.namespacePriority() {
+		conflict("namespacePriority", "priority %d, and %d", c.namespacePriority(), other.namespacePriority())
+	}
+
+	if c.NormalizeNames != other.NormalizeNames {
+		conflict("normalizeNames", "%v, and %v", c.NormalizeNames, other.NormalizeNames)
+	}
+
+	merged.Matchers = c.Matchers[:len(c.Matchers):len(c.Matchers)]
+	for _, m := range other.Matchers {
+		if m.JiraProject == "" && other.DefaultJiraProject != merged.DefaultJiraProject {
+			m.JiraProject = other.DefaultJiraProject
+		}
+		if m.JiraComponent == "" {
+			m.JiraComponent = other.DefaultJiraComponent
+		}
+		if len(other.DefaultCapabilities) > 0 {
+			m.Capabilities = unionStrings(m.Capabilities, other.DefaultCapabilities)
+		}
+		merged.Matchers = append(merged.Matchers, m)
+	}
+
+	if len(c.OperatorTestPatterns) > 0 || len(other.OperatorTestPatterns) > 0 {
+		merged.OperatorTestPatterns = make(map[string][]string)
+		for operator, patterns := range c.OperatorTestPatterns {
+			merged.OperatorTestPatterns[operator] = unionStrings(patterns, nil)
+		}
+		for operator, patterns := range other.OperatorTestPatterns {
+			merged.OperatorTestPatterns[operator] = unionStrings(merged.OperatorTestPatterns[operator], patterns)
+		}
+	}
+
+	if len(c.TestRenames) > 0 || len(other.TestRenames) > 0 {
+		merged.TestRenames = make(map[string]string, len(c.TestRenames)+len(other.TestRenames))
+		for from, to := range c.TestRenames {
+			merged.TestRenames[from] = to
+		}
+		for _, from := range sets.List(sets.KeySet(other.TestRenames)) {
+			to := other.TestRenames[from]
+			if existing, ok := merged.TestRenames[from]; ok && existing != to {
+				conflict(fmt.Sprintf("testRenames[%q]", from), "renamed to %q, and %q", existing, to)
+				continue
+			}
+			merged.TestRenames[from] = to
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return merged, nil
+}
+
+// unionStrings returns the strings in a followed by those in b, without duplicates.
+func unionStrings(a, b []string) []string {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	seen := sets.New[string]()
+	union := make([]string, 0, len(a)+len(b))
+	for _, str := range append(append([]string{}, a...), b...) {
+		if !seen.Has(str) {
+			seen.Insert(str)
+			union = append(union, str)
+		}
+	}
+	return union
+}
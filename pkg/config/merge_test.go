@@ -0,0 +1,152 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestComponent_Merge(t *testing.T) {
+	etcd := &Component{
+		Name:                 "Etcd",
+		DefaultJiraProject:   "OCPBUGS",
+		DefaultJiraComponent: "Etcd",
+		DefaultCapabilities:  []string{"Etcd"},
+		Matchers: []ComponentMatcher{
+			{SIG: "sig-etcd"},
+		},
+		Operators:   []string{"etcd"},
+		Namespaces:  []string{"openshift-etcd"},
+		Variants:    []string{"platform:aws"},
+		TestRenames: map[string]string{"[sig-etcd] new": "[sig-etcd] old"},
+	}
+	backup := &Component{
+		Name:                 "Etcd Backup",
+		DefaultJiraProject:   "OCPBUGS",
+		DefaultJiraComponent: "Etcd Backup",
+		DefaultCapabilities:  []string{"Backup"},
+		Matchers: []ComponentMatcher{
+			{IncludeAll: []string{"etcd backup"}},
+			{IncludeAll: []string{"restore"}, JiraComponent: "Restore", Capabilities: []string{"Restore"}},
+		},
+		Operators:   []string{"etcd", "cluster-backup"},
+		Namespaces:  []string{"openshift-etcd", "openshift-etcd-backup"},
+		Variants:    []string{"platform:aws", "platform:gcp"},
+		TestRenames: map[string]string{"etcd backup new": "etcd backup old"},
+	}
+
+	merged, err := etcd.Merge(backup)
+	if err != nil {
+		t.Fatalf("Merge() returned error: %v", err)
+	}
+
+	want := &Component{
+		Name:                 "Etcd",
+		DefaultJiraProject:   "OCPBUGS",
+		DefaultJiraComponent: "Etcd",
+		DefaultCapabilities:  []string{"Etcd"},
+		Matchers: []ComponentMatcher{
+			{SIG: "sig-etcd"},
+			{IncludeAll: []string{"etcd backup"}, JiraComponent: "Etcd Backup", Capabilities: []string{"Backup"}},
+			{IncludeAll: []string{"restore"}, JiraComponent: "Restore", Capabilities: []string{"Restore", "Backup"}},
+		},
+		Operators:  []string{"etcd", "cluster-backup"},
+		Namespaces: []string{"openshift-etcd", "openshift-etcd-backup"},
+		Variants:   []string{"platform:aws", "platform:gcp"},
+		TestRenames: map[string]string{
+			"[sig-etcd] new":  "[sig-etcd] old",
+			"etcd backup new": "etcd backup old",
+		},
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("Merge() = %+v, want %+v", merged, want)
+	}
+
+	if len(etcd.Matchers) != 1 || len(backup.Matchers[0].JiraComponent) != 0 {
+		t.Errorf("Merge() modified its components")
+	}
+
+	m := merged.FindMatch(&v1.TestInfo{Name: "[sig-api-machinery] etcd backup should succeed"})
+	if m == nil || m.JiraComponent != "Etcd Backup" {
+		t.Errorf("FindMatch() = %+v, want a match for Etcd Backup", m)
+	}
+}
+
+func TestComponent_MergeJiraDefaults(t *testing.T) {
+	c := &Component{Name: "Etcd"}
+	merged, err := c.Merge(&Component{Name: "Etcd Backup", DefaultJiraProject: "OCPBUGS", DefaultJiraComponent: "Etcd Backup"})
+	if err != nil {
+		t.Fatalf("Merge() returned error: %v", err)
+	}
+	if merged.DefaultJiraProject != "OCPBUGS" || merged.DefaultJiraComponent != "Etcd Backup" {
+		t.Errorf("Merge() Jira defaults = %q/%q, want OCPBUGS/Etcd Backup", merged.DefaultJiraProject, merged.DefaultJiraComponent)
+	}
+}
+
+func TestComponent_MergeJiraProjects(t *testing.T) {
+	c := &Component{Name: "Etcd", DefaultJiraProject: "OCPBUGS", DefaultJiraComponent: "Etcd"}
+	other := &Component{
+		Name:                 "Etcd Backup",
+		DefaultJiraProject:   "ETCD",
+		DefaultJiraComponent: "Backup",
+		Matchers: []ComponentMatcher{
+			{IncludeAll: []string{"etcd backup"}},
+			{IncludeAll: []string{"etcd restore"}, JiraProject: "OCPBUGS"},
+		},
+	}
+	merged, err := c.Merge(other)
+	if err != nil {
+		t.Fatalf("Merge() returned error: %v", err)
+	}
+	for name, want := range map[string]string{"etcd backup should succeed": "ETCD", "etcd restore should succeed": "OCPBUGS"} {
+		if got := merged.JiraProjectFor(merged.FindMatch(&v1.TestInfo{Name: name})); got != want {
+			t.Errorf("JiraProjectFor(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestComponent_MergeConflicts(t *testing.T) {
+	etcd := &Component{
+		Name:              "Etcd",
+		OwnsSIG:           []string{"sig-etcd"},
+		OwnsSIGPriority:   5,
+		Namespaces:        []string{"openshift-etcd"},
+		NamespacePriority: 20,
+		TestRenames: map[string]string{
+			"a": "b",
+			"c": "d",
+			"e": "f",
+		},
+	}
+	backup := &Component{
+		Name:       "Etcd Backup",
+		OwnsSIG:    []string{"sig-etcd-backup"},
+		Namespaces: []string{"openshift-etcd-backup"},
+		TestRenames: map[string]string{
+			"a": "x",
+			"c": "d",
+			"e": "y",
+		},
+	}
+
+	_, err := etcd.Merge(backup)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Merge() error = %v, want ValidationErrors", err)
+	}
+	var got []string
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		`component "Etcd" ownsSIGPriority: conflicts with component "Etcd Backup": priority 5, and 0`,
+		`component "Etcd" namespacePriority: conflicts with component "Etcd Backup": priority 20, and 10`,
+		`component "Etcd" testRenames["a"]: conflicts with component "Etcd Backup": renamed to "b", and "x"`,
+		`component "Etcd" testRenames["e"]: conflicts with component "Etcd Backup": renamed to "f", and "y"`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() errors = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// MatchStage is a stage of FindMatch timed by MatchMetrics.
+type MatchStage int
+
+const (
+	// MatchStageJira extracts the test's Jira fields and compares them to the component's.
+	MatchStageJira MatchStage = iota
+	// MatchStageOperator identifies operator tests.
+	MatchStageOperator
+	// MatchStageMatchers runs the Matchers, CustomMatchers and OwnsSIG.
+	MatchStageMatchers
+	// MatchStageNamespace falls back to namespace ownership.
+	MatchStageNamespace
+	// MatchStageJiraRegex falls back to the Jira component found by JiraComponentRegex.
+	MatchStageJiraRegex
+
+	// NumMatchStages is the number of stages, for iterating over them in order.
+	NumMatchStages
+)
+
+// String returns the stage's name, e.g. "operator".
+func (s MatchStage) String() string {
+	switch s {
+	case MatchStageJira:
+		return "jira"
+	case MatchStageOperator:
+		return "operator"
+	case MatchStageMatchers:
+		return "matchers"
+	case MatchStageNamespace:
+		return "namespace"
+	case MatchStageJiraRegex:
+		return "jira-regex"
+	}
+	return ""
+}
+
+// StageMetrics is the number of times a stage of FindMatch ran, and the total time it took.
+type StageMetrics struct {
+	Calls    int64
+	Duration time.Duration
+}
+
+// MatchMetricsCollector times the stages of FindMatch while enabled. It's safe for
+// concurrent use.
+type MatchMetricsCollector struct {
+	enabled atomic.Bool
+	calls   [NumMatchStages]atomic.Int64
+	nanos   [NumMatchStages]atomic.Int64
+}
+
+// MatchMetrics collects the metrics of every component's FindMatch, for profiling which
+// stage dominates a corpus. It's disabled by default, when it only costs FindMatch a check
+// of the flag per stage.
+var MatchMetrics = &MatchMetricsCollector{}
+
+// Enable starts collecting metrics.
+func (m *MatchMetricsCollector) Enable() {
+	m.enabled.Store(true)
+}
+
+// Disable stops collecting metrics, keeping those collected so far.
+func (m *MatchMetricsCollector) Disable() {
+	m.enabled.Store(false)
+}
+
+// Reset discards the metrics collected so far.
+func (m *MatchMetricsCollector) Reset() {
+	for stage := range m.calls {
+		m.calls[stage].Store(0)
+		m.nanos[stage].Store(0)
+	}
+}
+
+// Snapshot returns the metrics collected so far for each stage.
+func (m *MatchMetricsCollector) Snapshot() map[MatchStage]StageMetrics {
+	snapshot := make(map[MatchStage]StageMetrics, NumMatchStages)
+	for stage := MatchStage(0); stage < NumMatchStages; stage++ {
+		snapshot[stage] = StageMetrics{
+			Calls:    m.calls[stage].Load(),
+			Duration: time.Duration(m.nanos[stage].Load()),
+		}
+	}
+	return snapshot
+}
+
+// start returns the time a stage started, or the zero time if metrics are disabled.
+func (m *MatchMetricsCollector) start() time.Time {
+	if !m.enabled.Load() {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// done records a run of the stage that started at start, see start.
+func (m *MatchMetricsCollector) done(stage MatchStage, start time.Time) {
+	if start.IsZero() {
+		return
+	}
+	m.calls[stage].Add(1)
+	m.nanos[stage].Add(int64(time.Since(start)))
+}
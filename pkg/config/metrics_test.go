@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestMatchMetrics(t *testing.T) {
+	component := &Component{
+		DefaultJiraComponent: "Etcd",
+		Operators:            []string{"etcd"},
+		Namespaces:           []string{"openshift-etcd"},
+		Matchers:             []ComponentMatcher{{SIG: "sig-etcd"}},
+	}
+	tests := []*v1.TestInfo{
+		{Name: "[Jira:Etcd] should be healthy"},
+		{Name: "operator install etcd"},
+		{Name: "[sig-etcd] should be healthy"},
+		{Name: "alert/KubePodNotReady should not be at or above info in ns/openshift-etcd"},
+	}
+
+	MatchMetrics.Reset()
+	for _, test := range tests {
+		component.FindMatch(test)
+	}
+	for stage, metrics := range MatchMetrics.Snapshot() {
+		if metrics.Calls != 0 || metrics.Duration != 0 {
+			t.Errorf("disabled %s metrics = %+v, want none", stage, metrics)
+		}
+	}
+
+	MatchMetrics.Enable()
+	defer func() {
+		MatchMetrics.Disable()
+		MatchMetrics.Reset()
+	}()
+	for _, test := range tests {
+		component.FindMatch(test)
+	}
+	snapshot := MatchMetrics.Snapshot()
+	want := map[MatchStage]int64{
+		MatchStageJira:      4,
+		MatchStageOperator:  3,
+		MatchStageMatchers:  2,
+		MatchStageNamespace: 1,
+	}
+	for stage, calls := range want {
+		if got := snapshot[stage].Calls; got != calls {
+			t.Errorf("%s calls = %d, want %d", stage, got, calls)
+		}
+	}
+
+	MatchMetrics.Reset()
+	if got := MatchMetrics.Snapshot()[MatchStageJira]; got.Calls != 0 || got.Duration != 0 {
+		t.Errorf("Reset() left jira metrics %+v", got)
+	}
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// MatcherFailure describes why one of a component's matchers doesn't match a test, see
+// ExplainNonMatch.
+type MatcherFailure struct {
+	// MatcherIndex is the index of the matcher in the component's Matchers.
+	MatcherIndex int `json:"matcherIndex"`
+
+	// Conditions are the matcher's conditions that don't hold for the test, in the order
+	// they're listed on ComponentMatcher.
+	Conditions []ConditionFailure `json:"conditions"`
+}
+
+// ConditionFailure is a matcher condition that doesn't hold for a test.
+type ConditionFailure struct {
+	// Field is the serialized name of the matcher field, e.g. "includeAll".
+	Field string `json:"field"`
+	// Reason describes how the test fails the condition, e.g. `missing substring "ovn"`.
+	Reason string `json:"reason"`
+}
+
+func (f ConditionFailure) String() string {
+	return f.Field + ": " + f.Reason
+}
+
+// ExplainNonMatch returns, for each of the component's matchers that doesn't match the test,
+// every condition that fails, rather than stopping at the first like FindMatch. It's the
+// counterpart of Explain, for owners expecting their component to own a test it doesn't.
+// Matchers that match the test are omitted, so an empty result with no claim means another
+// stage, or another component at a higher priority, is deciding ownership. Like FindMatch,
+// names are normalized first if NormalizeNames is set.
+func ExplainNonMatch(c *Component, test *v1.TestInfo) []MatcherFailure {
+	matching := c.matchingTest(test)
+	var failures []MatcherFailure
+	matchers := c.EffectiveMatchers()
+	for i := range matchers {
+		cm := &matchers[i]
+		compiled := c.compiledMatcher(i)
+		if cm.matches(matching, compiled) {
+			continue
+		}
+
+		var conditions []ConditionFailure
+		if compiled == nil {
+			_, err := cm.compile()
+			conditions = []ConditionFailure{{Reason: fmt.Sprintf("matcher is invalid: %v", err)}}
+		} else if cm.Negate {
+			conditions = []ConditionFailure{{Field: "negate", Reason: "all of the conditions hold, and the matcher is negated"}}
+		} else {
+			e := conditionEvaluator{all: true}
+			cm.evaluateConditions(matching, compiled, &e)
+			conditions = e.failures
+		}
+		failures = append(failures, MatcherFailure{MatcherIndex: i, Conditions: conditions})
+	}
+	return failures
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestExplainNonMatch(t *testing.T) {
+	component := &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking",
+		Matchers: []ComponentMatcher{
+			{
+				SIG:        "sig-network",
+				IncludeAll: []string{"ovn|ovnkube", "egress"},
+				ExcludeAny: []string{"sdn", "flaky"},
+			},
+			{
+				SIG:        "sig-storage",
+				IncludeAny: []string{"volume"},
+			},
+			{
+				Suite:        "openshift-tests",
+				Tags:         map[string][]string{"Feature": {"EgressIP"}},
+				RequireFlags: []string{"Serial"},
+			},
+			{
+				NameMatchesRegex: `(`,
+			},
+		},
+	}
+	test := &v1.TestInfo{
+		Name:  "[sig-network][Feature:EgressIP] flaky sdn pods should be ready",
+		Suite: "openshift-tests",
+	}
+
+	want := []MatcherFailure{
+		{
+			MatcherIndex: 0,
+			Conditions: []ConditionFailure{
+				{Field: "includeAll", Reason: `missing substring "ovn|ovnkube"`},
+				{Field: "includeAll", Reason: `missing substring "egress"`},
+				{Field: "excludeAny", Reason: `excluded by "sdn"`},
+				{Field: "excludeAny", Reason: `excluded by "flaky"`},
+			},
+		},
+		{
+			MatcherIndex: 1,
+			Conditions: []ConditionFailure{
+				{Field: "sig", Reason: `test isn't in "sig-storage"`},
+				{Field: "includeAny", Reason: `has none of the substrings ["volume"]`},
+			},
+		},
+		{
+			MatcherIndex: 2,
+			Conditions: []ConditionFailure{
+				{Field: "requireFlags", Reason: "missing flag [Serial]"},
+			},
+		},
+		{
+			MatcherIndex: 3,
+			Conditions: []ConditionFailure{
+				{Reason: "matcher is invalid: nameMatchesRegex: error parsing regexp: missing closing ): `^(?:()$`"},
+			},
+		},
+	}
+	if got := ExplainNonMatch(component, test); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExplainNonMatch() = %+v, want %+v", got, want)
+	}
+
+	// Matching matchers are omitted.
+	test.Name = "[sig-network][Feature:EgressIP] ovn egress pods should be ready [Serial]"
+	if got := ExplainNonMatch(component, test); len(got) != 2 || got[0].MatcherIndex != 1 || got[1].MatcherIndex != 3 {
+		t.Errorf("ExplainNonMatch() = %+v, want failures for matchers 1 and 3", got)
+	}
+}
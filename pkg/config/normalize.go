@@ -0,0 +1,63 @@
+package config
+
+import (
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+var (
+	// normalizedTrailingTags are the keys of the bracketed tags NormalizeTestName strips from
+	// the end of test names, see SetNormalizedTrailingTags. The set is replaced rather than
+	// modified, so it's safe to use once read under normalizedTrailingTagsLock.
+	normalizedTrailingTags     = sets.New[string]("Suite")
+	normalizedTrailingTagsLock sync.RWMutex
+)
+
+// SetNormalizedTrailingTags replaces the keys of the bracketed tags NormalizeTestName strips
+// from the end of test names, by default just "Suite", e.g.
+// [Suite:openshift/conformance/parallel]. It's safe to call while tests are being matched.
+func SetNormalizedTrailingTags(keys ...string) {
+	normalizedTrailingTagsLock.Lock()
+	defer normalizedTrailingTagsLock.Unlock()
+	normalizedTrailingTags = sets.New[string](keys...)
+	invalidateMatchCaches()
+}
+
+// NormalizeTestName collapses each run of whitespace in the test name to a single space,
+// trims it, and strips any trailing bracketed tags with one of the keys set by
+// SetNormalizedTrailingTags, e.g. "[sig-etcd]  etcd is healthy [Suite:openshift]" is
+// normalized to "[sig-etcd] etcd is healthy".
+func NormalizeTestName(name string) string {
+	normalizedTrailingTagsLock.RLock()
+	keys := normalizedTrailingTags
+	normalizedTrailingTagsLock.RUnlock()
+
+	name = strings.Join(strings.Fields(name), " ")
+	for strings.HasSuffix(name, "]") {
+		start := strings.LastIndexByte(name, '[')
+		if start < 0 {
+			break
+		}
+		key, _, _ := strings.Cut(name[start+1:len(name)-1], ":")
+		if !keys.Has(key) {
+			break
+		}
+		name = strings.TrimRight(name[:start], " ")
+	}
+	return name
+}
+
+// matchingTest returns the test to compare the matchers to: the test itself, or a copy
+// with its name normalized if the component has NormalizeNames set.
+func (c *Component) matchingTest(test *v1.TestInfo) *v1.TestInfo {
+	if !c.NormalizeNames {
+		return test
+	}
+	normalized := *test
+	normalized.Name = NormalizeTestName(test.Name)
+	return &normalized
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestNormalizeTestName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "[sig-etcd] etcd is healthy",
+			want: "[sig-etcd] etcd is healthy",
+		},
+		{
+			name: " [sig-etcd]  etcd\tis   healthy ",
+			want: "[sig-etcd] etcd is healthy",
+		},
+		{
+			name: "[sig-etcd] etcd is healthy [Suite:openshift/conformance/parallel]",
+			want: "[sig-etcd] etcd is healthy",
+		},
+		{
+			name: "[sig-etcd] etcd is healthy [Suite:openshift/conformance/parallel] [Suite:k8s]",
+			want: "[sig-etcd] etcd is healthy",
+		},
+		{
+			name: "[sig-etcd] etcd is healthy [Serial] [Suite:openshift/conformance/serial]",
+			want: "[sig-etcd] etcd is healthy [Serial]",
+		},
+		{
+			name: "[sig-etcd] etcd is healthy [Suite:openshift/conformance/parallel] [Serial]",
+			want: "[sig-etcd] etcd is healthy [Suite:openshift/conformance/parallel] [Serial]",
+		},
+		{
+			name: "[Suite:openshift]",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTestName(tt.name); got != tt.want {
+				t.Errorf("NormalizeTestName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetNormalizedTrailingTags(t *testing.T) {
+	defer SetNormalizedTrailingTags("Suite")
+
+	SetNormalizedTrailingTags("Suite", "Serial")
+	name := "[sig-etcd] etcd is healthy [Serial] [Suite:openshift/conformance/serial]"
+	if got, want := NormalizeTestName(name), "[sig-etcd] etcd is healthy"; got != want {
+		t.Errorf("NormalizeTestName(%q) = %q, want %q", name, got, want)
+	}
+
+	SetNormalizedTrailingTags()
+	if got, want := NormalizeTestName(name), name; got != want {
+		t.Errorf("NormalizeTestName(%q) = %q, want %q", name, got, want)
+	}
+}
+
+func TestComponent_NormalizeNames(t *testing.T) {
+	test := &v1.TestInfo{Name: "[sig-etcd]  etcd is healthy [Suite:openshift/conformance/parallel]"}
+	for _, normalize := range []bool{false, true} {
+		c := &Component{
+			Name:                 "Etcd",
+			DefaultJiraComponent: "Etcd",
+			NormalizeNames:       normalize,
+			Matchers: []ComponentMatcher{
+				{IncludeAll: []string{"] etcd is"}, NameSuffix: "healthy"},
+			},
+		}
+		if err := c.Compile(); err != nil {
+			t.Fatalf("Compile() returned error: %v", err)
+		}
+		if got := c.FindMatch(test) != nil; got != normalize {
+			t.Errorf("NormalizeNames %v: FindMatch() matched = %v, want %v", normalize, got, normalize)
+		}
+	}
+}
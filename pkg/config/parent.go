@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+)
+
+// LinkParents resolves each component's Parent to the component of that name, so Resolve
+// can defer ancestors' fallback claims to their descendants. It's intended to be called once
+// the components are loaded, and returns a ValidationErrors listing every component whose
+// Parent doesn't exist or whose ancestry contains a cycle, in which case no parents are
+// linked.
+func LinkParents(components []*Component) error {
+	byName := make(map[string]*Component, len(components))
+	for _, c := range components {
+		byName[c.Name] = c
+	}
+
+	var errs ValidationErrors
+	parents := make(map[*Component]*Component)
+	for _, c := range components {
+		if c.Parent == "" {
+			continue
+		}
+		parent, ok := byName[c.Parent]
+		if !ok {
+			errs = append(errs, &ConfigError{Component: c.Name, Field: "parent", Reason: fmt.Sprintf("no component named %q", c.Parent)})
+			continue
+		}
+		parents[c] = parent
+	}
+
+	for _, c := range components {
+		seen := map[*Component]bool{c: true}
+		chain := []string{c.Name}
+		for ancestor := parents[c]; ancestor != nil; ancestor = parents[ancestor] {
+			chain = append(chain, ancestor.Name)
+			if seen[ancestor] {
+				errs = append(errs, &ConfigError{Component: c.Name, Field: "parent", Reason: fmt.Sprintf("parents contain a cycle: %q", chain)})
+				break
+			}
+			seen[ancestor] = true
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	for _, c := range components {
+		c.parent = parents[c]
+	}
+	return nil
+}
+
+// isAncestor reports whether the component is one of c's linked ancestors, see LinkParents.
+func (c *Component) isAncestor(component *Component) bool {
+	for ancestor := c.parent; ancestor != nil; ancestor = ancestor.parent {
+		if ancestor == component {
+			return true
+		}
+	}
+	return false
+}
+
+// isFallback reports whether the claim is a fallback an ancestor's claim defers to its
+// descendants' claims: an OwnsSIG match, or one by a matcher with a negative priority.
+func (cl *claim) isFallback() bool {
+	return cl.source == MatchSourceSIG || cl.matcher.EffectivePriority() < PriorityDefault
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestLinkParents(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []*Component
+		want       []ConfigError
+	}{
+		{
+			name: "linked",
+			components: []*Component{
+				{Name: "Storage"},
+				{Name: "CSI", Parent: "Storage"},
+				{Name: "CSI Snapshots", Parent: "CSI"},
+			},
+		},
+		{
+			name: "unknown parent",
+			components: []*Component{
+				{Name: "CSI", Parent: "Storage"},
+			},
+			want: []ConfigError{
+				{Component: "CSI", Field: "parent", Reason: `no component named "Storage"`},
+			},
+		},
+		{
+			name: "cycle",
+			components: []*Component{
+				{Name: "Storage", Parent: "CSI"},
+				{Name: "CSI", Parent: "Storage"},
+				{Name: "Etcd"},
+			},
+			want: []ConfigError{
+				{Component: "Storage", Field: "parent", Reason: `parents contain a cycle: ["Storage" "CSI" "Storage"]`},
+				{Component: "CSI", Field: "parent", Reason: `parents contain a cycle: ["CSI" "Storage" "CSI"]`},
+			},
+		},
+		{
+			name: "own parent",
+			components: []*Component{
+				{Name: "Storage", Parent: "Storage"},
+			},
+			want: []ConfigError{
+				{Component: "Storage", Field: "parent", Reason: `parents contain a cycle: ["Storage" "Storage"]`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LinkParents(tt.components)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("LinkParents() returned error: %v", err)
+				}
+				for _, c := range tt.components {
+					if c.Parent != "" && (c.parent == nil || c.parent.Name != c.Parent) {
+						t.Errorf("component %q parent = %v, want %q", c.Name, c.parent, c.Parent)
+					}
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) || len(errs) != len(tt.want) {
+				t.Fatalf("LinkParents() error = %v, want %d errors", err, len(tt.want))
+			}
+			for i, err := range errs {
+				if got := err.(*ConfigError); *got != tt.want[i] {
+					t.Errorf("error %d = %+v, want %+v", i, *got, tt.want[i])
+				}
+			}
+			for _, c := range tt.components {
+				if c.parent != nil {
+					t.Errorf("component %q was linked despite errors", c.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveParents(t *testing.T) {
+	storage := &Component{
+		Name:     "Storage",
+		OwnsSIG:  []string{"sig-storage"},
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"storage class"}, Priority: -1}},
+	}
+	csi := &Component{
+		Name:     "CSI",
+		Parent:   "Storage",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"CSI"}, Priority: -5}},
+	}
+	snapshots := &Component{
+		Name:     "CSI Snapshots",
+		Parent:   "CSI",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"snapshot"}}},
+	}
+	other := &Component{
+		Name:     "Other",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"storage class"}, Priority: -2}},
+	}
+	if err := LinkParents([]*Component{storage, csi, snapshots, other}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		components    []*Component
+		test          v1.TestInfo
+		wantComponent string
+	}{
+		{
+			name:          "child takes precedence over parent's sig",
+			components:    []*Component{storage, csi},
+			test:          v1.TestInfo{Name: "[sig-storage] CSI volumes should mount"},
+			wantComponent: "CSI",
+		},
+		{
+			name:          "parent owns what children don't claim",
+			components:    []*Component{storage, csi},
+			test:          v1.TestInfo{Name: "[sig-storage] in-tree volumes should mount"},
+			wantComponent: "Storage",
+		},
+		{
+			name:          "grandchild takes precedence over grandparent's catch-all",
+			components:    []*Component{storage, snapshots},
+			test:          v1.TestInfo{Name: "storage class snapshot should be restored"},
+			wantComponent: "CSI Snapshots",
+		},
+		{
+			name:          "unrelated components still compete on priority",
+			components:    []*Component{storage, other},
+			test:          v1.TestInfo{Name: "storage class should be default"},
+			wantComponent: "Storage",
+		},
+		{
+			name:          "unclaimed test falls back to an ancestor not among components",
+			components:    []*Component{snapshots},
+			test:          v1.TestInfo{Name: "[sig-storage] in-tree volumes should mount"},
+			wantComponent: "Storage",
+		},
+		{
+			name:          "unclaimed test falls back to the nearest ancestor",
+			components:    []*Component{snapshots},
+			test:          v1.TestInfo{Name: "[sig-storage] CSI volumes should mount"},
+			wantComponent: "CSI",
+		},
+		{
+			name:       "unclaimed test without an ancestor claim",
+			components: []*Component{snapshots},
+			test:       v1.TestInfo{Name: "[sig-network] pods should communicate"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := Resolve(tt.components, &tt.test)
+			got := ""
+			if c != nil {
+				got = c.Name
+			}
+			if got != tt.wantComponent {
+				t.Errorf("Resolve() = %q, want %q", got, tt.wantComponent)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// FindMatchForRelease is like FindMatch, for a test run on the release, e.g. "4.14": matchers
+// whose EffectiveFrom and EffectiveUntil exclude the release are skipped. With an empty
+// release, every matcher is active, as with FindMatch.
+func (c *Component) FindMatchForRelease(test *v1.TestInfo, release string) *ComponentMatcher {
+	m, _ := c.findMatchWithReason(test, release)
+	return m
+}
+
+// activeIn reports whether the matcher is effective in the release, see EffectiveFrom. Every
+// matcher is active in the empty release, and matchers without an effective range are
+// active in every release. A release that isn't a version is outside every range.
+func (cm *ComponentMatcher) activeIn(release string) bool {
+	if release == "" || (cm.EffectiveFrom == "" && cm.EffectiveUntil == "") {
+		return true
+	}
+	version, err := parseRelease(release)
+	if err != nil {
+		return false
+	}
+	if cm.EffectiveFrom != "" {
+		if from, err := parseRelease(cm.EffectiveFrom); err != nil || compareReleases(version, from) < 0 {
+			return false
+		}
+	}
+	if cm.EffectiveUntil != "" {
+		if until, err := parseRelease(cm.EffectiveUntil); err != nil || compareReleases(version, until) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// validateEffectiveRange checks the matcher's EffectiveFrom and EffectiveUntil are
+// releases, in order.
+func (cm *ComponentMatcher) validateEffectiveRange() []matcherError {
+	var errs []matcherError
+	var from, until []int
+	var err error
+	if cm.EffectiveFrom != "" {
+		if from, err = parseRelease(cm.EffectiveFrom); err != nil {
+			errs = append(errs, matcherError{"effectiveFrom", err})
+		}
+	}
+	if cm.EffectiveUntil != "" {
+		if until, err = parseRelease(cm.EffectiveUntil); err != nil {
+			errs = append(errs, matcherError{"effectiveUntil", err})
+		}
+	}
+	if from != nil && until != nil && compareReleases(from, until) >= 0 {
+		errs = append(errs, matcherError{"effectiveUntil", fmt.Errorf("release %q is not after effectiveFrom %q", cm.EffectiveUntil, cm.EffectiveFrom)})
+	}
+	return errs
+}
+
+// parseRelease parses a release formatted as dot-separated numbers, e.g. "4.14".
+func parseRelease(release string) ([]int, error) {
+	parts := strings.Split(release, ".")
+	version := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || strings.HasPrefix(part, "+") {
+			return nil, fmt.Errorf("release %q is not formatted as dot-separated numbers, e.g. 4.14", release)
+		}
+		version[i] = n
+	}
+	return version, nil
+}
+
+// compareReleases returns -1, 0 or 1 as release a is before, the same as, or after release
+// b. Missing trailing numbers are zero, so "4.14" is the same as "4.14.0".
+func compareReleases(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
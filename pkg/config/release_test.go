@@ -0,0 +1,75 @@
+package config
+
+import (
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestComponent_FindMatchForRelease(t *testing.T) {
+	component := &Component{
+		DefaultJiraComponent: "Networking",
+		Matchers: []ComponentMatcher{
+			{IncludeAll: []string{"egress"}, JiraComponent: "Networking / openshift-sdn", EffectiveUntil: "4.15"},
+			{IncludeAll: []string{"egress"}, JiraComponent: "Networking / ovn-kubernetes", EffectiveFrom: "4.12", Priority: 1},
+			{IncludeAll: []string{"egress"}, JiraComponent: "Networking / egress", EffectiveFrom: "4.14", EffectiveUntil: "4.16", Priority: 2},
+		},
+	}
+	if err := component.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	test := &v1.TestInfo{Name: "[sig-network] egress should work"}
+
+	tests := []struct {
+		release string
+		want    string
+	}{
+		{release: "", want: "Networking / egress"},
+		{release: "4.11", want: "Networking / openshift-sdn"},
+		{release: "4.12", want: "Networking / ovn-kubernetes"},
+		{release: "4.13.9", want: "Networking / ovn-kubernetes"},
+		{release: "4.14", want: "Networking / egress"},
+		{release: "4.15.0", want: "Networking / egress"},
+		{release: "4.16", want: "Networking / ovn-kubernetes"},
+		{release: "4.x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.release, func(t *testing.T) {
+			got := component.FindMatchForRelease(test, tt.release)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("FindMatchForRelease() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.JiraComponent != tt.want {
+				t.Errorf("FindMatchForRelease() = %+v, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareReleases(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "4.14", b: "4.14.0", want: 0},
+		{a: "4.9", b: "4.14", want: -1},
+		{a: "5", b: "4.99", want: 1},
+		{a: "4.14.1", b: "4.14", want: 1},
+	}
+	for _, tt := range tests {
+		a, err := parseRelease(tt.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := parseRelease(tt.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := compareReleases(a, b); got != tt.want {
+			t.Errorf("compareReleases(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift-eng/ci-test-mapping/pkg/util"
+)
+
+// MergeTestRenames combines TestRenames maps, e.g. kept in separate files by release, into
+// one. Entries repeated with the same target are fine, but if the same name is renamed to
+// different targets, it returns a ValidationErrors listing every conflict, sorted by name.
+func MergeTestRenames(maps ...map[string]string) (map[string]string, error) {
+	merged := make(map[string]string)
+	conflicts := make(map[string]sets.Set[string])
+	for _, renames := range maps {
+		for from, to := range renames {
+			existing, ok := merged[from]
+			if !ok {
+				merged[from] = to
+				continue
+			}
+			if existing != to {
+				if conflicts[from] == nil {
+					conflicts[from] = sets.New[string](existing)
+				}
+				conflicts[from].Insert(to)
+			}
+		}
+	}
+
+	if len(conflicts) == 0 {
+		return merged, nil
+	}
+	var errs ValidationErrors
+	for _, from := range sets.List(sets.KeySet(conflicts)) {
+		errs = append(errs, fmt.Errorf("test %q is renamed to more than one name: %q", from, sets.List(conflicts[from])))
+	}
+	return nil, errs
+}
+
+// AddTestRenames merges the renames into the component's TestRenames, see
+// MergeTestRenames. If any conflict, with each other or with the existing TestRenames,
+// the component is left unchanged.
+func (c *Component) AddTestRenames(maps ...map[string]string) error {
+	merged, err := MergeTestRenames(append([]map[string]string{c.TestRenames}, maps...)...)
+	if err != nil {
+		return fmt.Errorf("component %q: %w", c.Name, err)
+	}
+	c.TestRenames = merged
+	return nil
+}
+
+// BestRenameCandidate finds the candidate most similar to a test name, to help suggest
+// TestRenames entries for tests that were slightly reworded. Similarity is the Jaccard
+// index of the two names' word sets, ignoring bracketed tags and case, so reordered words
+// or an added [Serial] tag don't count against a candidate. It returns the best candidate
+// whose similarity is at least threshold, and the similarity, or an empty string and 0 if
+// no candidate is similar enough. Ties go to the earliest candidate.
+func BestRenameCandidate(name string, candidates []string, threshold float64) (string, float64) {
+	tokens := renameTokens(name)
+	best, bestScore := "", 0.0
+	for _, candidate := range candidates {
+		score := jaccard(tokens, renameTokens(candidate))
+		if score >= threshold && score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	return best, bestScore
+}
+
+// renameTokens returns the lowercased words of a test name, without bracketed tags.
+func renameTokens(name string) sets.Set[string] {
+	return sets.New[string](strings.Fields(strings.ToLower(util.StripTags(name)))...)
+}
+
+// jaccard returns the size of the intersection of a and b divided by the size of their
+// union, or 0 if both are empty.
+func jaccard(a, b sets.Set[string]) float64 {
+	union := a.Union(b).Len()
+	if union == 0 {
+		return 0
+	}
+	return float64(a.Intersection(b).Len()) / float64(union)
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBestRenameCandidate(t *testing.T) {
+	candidates := []string{
+		"[sig-storage] CSI volumes should be mountable [Suite:openshift/conformance/parallel]",
+		"[sig-storage] In-tree volumes should be resizable",
+		"[sig-network] Services should serve endpoints",
+	}
+
+	tests := []struct {
+		name      string
+		test      string
+		threshold float64
+		want      string
+		wantScore float64
+	}{
+		{
+			name:      "identical after stripping tags",
+			test:      "[sig-storage][Serial] CSI volumes should be mountable",
+			threshold: 0.8,
+			want:      candidates[0],
+			wantScore: 1,
+		},
+		{
+			name:      "reordered words",
+			test:      "[sig-storage] volumes In-tree should be resizable",
+			threshold: 0.8,
+			want:      candidates[1],
+			wantScore: 1,
+		},
+		{
+			name:      "slightly reworded",
+			test:      "[sig-storage] CSI volumes should be quickly mountable",
+			threshold: 0.8,
+			want:      candidates[0],
+			wantScore: 5.0 / 6.0,
+		},
+		{
+			name:      "below threshold",
+			test:      "[sig-storage] CSI snapshots are restorable",
+			threshold: 0.5,
+			want:      "",
+			wantScore: 0,
+		},
+		{
+			name:      "only tags",
+			test:      "[sig-storage][Serial]",
+			threshold: 0,
+			want:      "",
+			wantScore: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, score := BestRenameCandidate(tt.test, candidates, tt.threshold)
+			if got != tt.want || score != tt.wantScore {
+				t.Errorf("BestRenameCandidate() = %q, %v, want %q, %v", got, score, tt.want, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestMergeTestRenames(t *testing.T) {
+	renames415 := map[string]string{
+		"[sig-etcd] etcd is healthy": "[sig-etcd] etcd should be healthy",
+		"[sig-etcd] backup succeeds": "[sig-etcd] backup should succeed",
+	}
+	renames416 := map[string]string{
+		"[sig-etcd] etcd is healthy": "[sig-etcd] etcd should be healthy",
+		"[sig-etcd] etcd has quorum": "[sig-etcd] quorum should hold",
+	}
+
+	merged, err := MergeTestRenames(renames415, renames416, nil)
+	if err != nil {
+		t.Fatalf("MergeTestRenames() returned error: %v", err)
+	}
+	want := map[string]string{
+		"[sig-etcd] etcd is healthy": "[sig-etcd] etcd should be healthy",
+		"[sig-etcd] backup succeeds": "[sig-etcd] backup should succeed",
+		"[sig-etcd] etcd has quorum": "[sig-etcd] quorum should hold",
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("MergeTestRenames() = %v, want %v", merged, want)
+	}
+
+	_, err = MergeTestRenames(renames415, renames416, map[string]string{
+		"[sig-etcd] etcd has quorum": "[sig-etcd] etcd quorum holds",
+		"[sig-etcd] backup succeeds": "[sig-etcd] backups succeed",
+	}, map[string]string{
+		"[sig-etcd] backup succeeds": "[sig-etcd] backup is successful",
+	})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("MergeTestRenames() error = %v, want ValidationErrors", err)
+	}
+	wantErrs := []string{
+		`test "[sig-etcd] backup succeeds" is renamed to more than one name: ["[sig-etcd] backup is successful" "[sig-etcd] backup should succeed" "[sig-etcd] backups succeed"]`,
+		`test "[sig-etcd] etcd has quorum" is renamed to more than one name: ["[sig-etcd] etcd quorum holds" "[sig-etcd] quorum should hold"]`,
+	}
+	var got []string
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	if !reflect.DeepEqual(got, wantErrs) {
+		t.Errorf("MergeTestRenames() errors = %q, want %q", got, wantErrs)
+	}
+}
+
+func TestComponent_AddTestRenames(t *testing.T) {
+	c := &Component{
+		Name:        "Etcd",
+		TestRenames: map[string]string{"[sig-etcd] etcd is healthy": "[sig-etcd] etcd should be healthy"},
+	}
+	if err := c.AddTestRenames(map[string]string{"[sig-etcd] etcd should be healthy": "[sig-etcd] etcd healthy"}); err != nil {
+		t.Fatalf("AddTestRenames() returned error: %v", err)
+	}
+	if got, want := c.CanonicalName("[sig-etcd] etcd is healthy"), "[sig-etcd] etcd healthy"; got != want {
+		t.Errorf("CanonicalName() = %q, want %q", got, want)
+	}
+
+	if err := c.AddTestRenames(map[string]string{"[sig-etcd] etcd is healthy": "[sig-etcd] etcd is fine"}); err == nil {
+		t.Errorf("AddTestRenames() didn't return an error for a conflicting rename")
+	}
+	if got, want := len(c.TestRenames), 2; got != want {
+		t.Errorf("AddTestRenames() left %d renames after a conflict, want %d", got, want)
+	}
+}
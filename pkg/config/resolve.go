@@ -0,0 +1,301 @@
+package config
+
+import (
+	"sort"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// Overlap describes a test claimed by more than one component at the same, highest,
+// priority.
+type Overlap struct {
+	// TestName and Suite identify the test.
+	TestName string
+	Suite    string
+
+	// Components are the names of the competing components, sorted.
+	Components []string
+
+	// Priority is the priority shared by the competing components.
+	Priority int
+}
+
+// claim is a component's match on a test.
+type claim struct {
+	component *Component
+	matcher   *ComponentMatcher
+	source    MatchSource
+}
+
+// findClaims returns every component's match on the test, without the fallback claims of
+// ancestors of components that also claim it, see Resolve. If no component claims the
+// test, it returns the fallback claims of the components' ancestors instead. It returns
+// nil for ignored tests, see SetIgnorePatterns.
+func findClaims(components []*Component, test *v1.TestInfo) []claim {
+	if IsIgnoredTest(test) {
+		return nil
+	}
+	var claims []claim
+	for _, c := range components {
+		if m, reason := c.FindMatchWithReason(test); m != nil {
+			claims = append(claims, claim{component: c, matcher: m, source: reason.Source})
+		}
+	}
+	if len(claims) == 0 {
+		return findAncestorFallbacks(components, test)
+	}
+	return withoutAncestorFallbacks(claims)
+}
+
+// findAncestorFallbacks returns, for each component, the fallback claim on the test of its
+// nearest ancestor to make one, see LinkParents.
+func findAncestorFallbacks(components []*Component, test *v1.TestInfo) []claim {
+	var claims []claim
+	seen := make(map[*Component]bool)
+	for _, c := range components {
+		for ancestor := c.parent; ancestor != nil; ancestor = ancestor.parent {
+			m, reason := ancestor.FindMatchWithReason(test)
+			if m == nil {
+				continue
+			}
+			cl := claim{component: ancestor, matcher: m, source: reason.Source}
+			if cl.isFallback() {
+				if !seen[ancestor] {
+					seen[ancestor] = true
+					claims = append(claims, cl)
+				}
+				break
+			}
+		}
+	}
+	return claims
+}
+
+// withoutAncestorFallbacks removes the fallback claims made by an ancestor of another
+// claimant, see LinkParents.
+func withoutAncestorFallbacks(claims []claim) []claim {
+	kept := claims[:0]
+	for i := range claims {
+		deferred := false
+		if claims[i].isFallback() {
+			for j := range claims {
+				if claims[j].component.isAncestor(claims[i].component) {
+					deferred = true
+					break
+				}
+			}
+		}
+		if !deferred {
+			kept = append(kept, claims[i])
+		}
+	}
+	return kept
+}
+
+// Resolve selects the component that owns the test, and the winning matcher, among
+// every component that claims it. The highest priority wins. When priorities are equal,
+// the more specific matcher (see ComponentMatcher.Specificity) wins, and if that's still a
+// tie, the component whose name sorts first wins, so the result never depends on the
+// order of components. It returns nil if no component claims the test.
+//
+// Components linked to a parent by LinkParents take precedence over their ancestors'
+// fallback rules, OwnsSIG and matchers with negative priorities, regardless of priority,
+// so an umbrella component owns whatever its sub-components don't claim. If none of
+// components claims the test, Resolve walks up their parents to the nearest one whose
+// fallback rules claim it, even if it isn't among components.
+//
+// No component owns a test ignored by SetIgnorePatterns.
+func Resolve(components []*Component, test *v1.TestInfo) (*Component, *ComponentMatcher) {
+	winner := resolveClaims(findClaims(components, test))
+	if winner == nil {
+		return nil, nil
+	}
+	return winner.component, winner.matcher
+}
+
+// OwnershipResult is one of the owners of a test, see FindAllOwners.
+type OwnershipResult struct {
+	// Component and Matcher are the owning component and its matcher.
+	Component *Component
+	Matcher   *ComponentMatcher
+
+	// Source is the stage of FindMatch that claimed the test.
+	Source MatchSource
+}
+
+// FindAllOwners is like Resolve, but for reports showing co-ownership, it returns every
+// component that claims the test at the highest priority rather than a single winner. The
+// owners are in the order Resolve ranks them, so the first is the owner Resolve returns.
+// It returns nil if no component claims the test.
+func FindAllOwners(components []*Component, test *v1.TestInfo) []OwnershipResult {
+	claims := findClaims(components, test)
+	if len(claims) == 0 {
+		return nil
+	}
+
+	highest := claims[0].matcher.EffectivePriority()
+	for _, cl := range claims[1:] {
+		if p := cl.matcher.EffectivePriority(); p > highest {
+			highest = p
+		}
+	}
+
+	var top []claim
+	for _, cl := range claims {
+		if cl.matcher.EffectivePriority() == highest {
+			top = append(top, cl)
+		}
+	}
+	sort.Slice(top, func(i, j int) bool { return top[i].beats(&top[j]) })
+
+	owners := make([]OwnershipResult, 0, len(top))
+	for _, cl := range top {
+		owners = append(owners, OwnershipResult{Component: cl.component, Matcher: cl.matcher, Source: cl.source})
+	}
+	return owners
+}
+
+// OwnerForTestName is like Resolve, for a test identified only by its name and suite. It's
+// intended for debugging which component owns a test.
+func OwnerForTestName(components []*Component, name, suite string) (*Component, *ComponentMatcher) {
+	return Resolve(components, &v1.TestInfo{Name: name, Suite: suite})
+}
+
+// resolveClaims returns the winning claim, see Resolve.
+func resolveClaims(claims []claim) *claim {
+	var winner *claim
+	for i := range claims {
+		if winner == nil || claims[i].beats(winner) {
+			winner = &claims[i]
+		}
+	}
+	return winner
+}
+
+// beats reports whether cl takes precedence over other.
+func (cl *claim) beats(other *claim) bool {
+	if a, b := cl.matcher.EffectivePriority(), other.matcher.EffectivePriority(); a != b {
+		return a > b
+	}
+	if a, b := cl.matcher.Specificity(), other.matcher.Specificity(); a != b {
+		return a > b
+	}
+	return cl.component.Name < other.component.Name
+}
+
+// DetectOverlaps reports every test in the corpus that's claimed by more than one
+// component at the highest priority any component claimed it with. Such tests are
+// ambiguous, as no priority decides their ownership.
+func DetectOverlaps(components []*Component, tests []*v1.TestInfo) []Overlap {
+	var overlaps []Overlap
+	for _, test := range tests {
+		if overlap := findOverlap(test, findClaims(components, test)); overlap != nil {
+			overlaps = append(overlaps, *overlap)
+		}
+	}
+
+	return overlaps
+}
+
+// findOverlap returns the overlap between the claims on the test, or nil if there's a
+// single highest priority claim.
+func findOverlap(test *v1.TestInfo, claims []claim) *Overlap {
+	if len(claims) < 2 {
+		return nil
+	}
+
+	highest := claims[0].matcher.EffectivePriority()
+	for _, cl := range claims[1:] {
+		if p := cl.matcher.EffectivePriority(); p > highest {
+			highest = p
+		}
+	}
+
+	var names []string
+	for _, cl := range claims {
+		if cl.matcher.EffectivePriority() == highest {
+			names = append(names, cl.component.Name)
+		}
+	}
+	if len(names) < 2 {
+		return nil
+	}
+
+	sort.Strings(names)
+	return &Overlap{
+		TestName:   test.Name,
+		Suite:      test.Suite,
+		Components: names,
+		Priority:   highest,
+	}
+}
+
+// ConflictReport is a test ValidateCoverage found claimed by more than one component at
+// the same, highest, priority.
+type ConflictReport = Overlap
+
+// ValidateCoverage checks that the components assign every test in the corpus exactly one
+// owner, in a single pass over the corpus. It returns the names of the tests no component
+// claims, see UnmatchedTests, and the tests claimed by more than one component at the same
+// priority, see DetectOverlaps. Both are empty when the mapping is total and unambiguous,
+// which is the check to run in CI. Ignored tests, see SetIgnorePatterns, are neither.
+func ValidateCoverage(components []*Component, tests []*v1.TestInfo) (unmatched []string, conflicts []ConflictReport) {
+	for _, test := range tests {
+		if IsIgnoredTest(test) {
+			continue
+		}
+		claims := findClaims(components, test)
+		if len(claims) == 0 {
+			unmatched = append(unmatched, test.Name)
+			continue
+		}
+		if overlap := findOverlap(test, claims); overlap != nil {
+			conflicts = append(conflicts, *overlap)
+		}
+	}
+	return unmatched, conflicts
+}
+
+// OwnedTests returns every test in the corpus that the target owns once resolved against
+// all the components, see Resolve, in corpus order. Tests the target matches, but another
+// component claims with a higher priority, aren't included. The target competes even if
+// it isn't among all.
+func OwnedTests(target *Component, all []*Component, tests []*v1.TestInfo) []*v1.TestInfo {
+	components := all
+	if !containsComponent(all, target) {
+		components = append(all[:len(all):len(all)], target)
+	}
+
+	var owned []*v1.TestInfo
+	for _, test := range tests {
+		if owner, _ := Resolve(components, test); owner == target {
+			owned = append(owned, test)
+		}
+	}
+	return owned
+}
+
+// containsComponent reports whether the component is one of components.
+func containsComponent(components []*Component, component *Component) bool {
+	for _, c := range components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmatchedTests returns every test in the corpus that no component claims, other than
+// those ignored by SetIgnorePatterns.
+func UnmatchedTests(components []*Component, tests []*v1.TestInfo) []*v1.TestInfo {
+	var unmatched []*v1.TestInfo
+	for _, test := range tests {
+		if IsIgnoredTest(test) {
+			continue
+		}
+		if component, _ := Resolve(components, test); component == nil {
+			unmatched = append(unmatched, test)
+		}
+	}
+	return unmatched
+}
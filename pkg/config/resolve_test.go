@@ -0,0 +1,476 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestResolve(t *testing.T) {
+	sigStorage := &Component{
+		Name:     "Storage",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage"}},
+	}
+	csi := &Component{
+		Name:     "CSI",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage", IncludeAny: []string{"CSI"}}},
+	}
+	aardvark := &Component{
+		Name:     "Aardvark",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"in-tree"}}},
+	}
+	zebra := &Component{
+		Name:     "Zebra",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"in-tree"}}},
+	}
+	priority := &Component{
+		Name:     "Priority",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"priority"}, Priority: 1}},
+	}
+
+	tests := []struct {
+		name          string
+		components    []*Component
+		test          v1.TestInfo
+		wantComponent string
+	}{
+		{
+			name:          "higher priority wins",
+			components:    []*Component{csi, priority},
+			test:          v1.TestInfo{Name: "[sig-storage] CSI priority volumes should mount"},
+			wantComponent: "Priority",
+		},
+		{
+			name:          "more specific matcher wins on equal priority",
+			components:    []*Component{sigStorage, csi},
+			test:          v1.TestInfo{Name: "[sig-storage] CSI volumes should mount"},
+			wantComponent: "CSI",
+		},
+		{
+			name:          "more specific matcher wins regardless of component order",
+			components:    []*Component{csi, sigStorage},
+			test:          v1.TestInfo{Name: "[sig-storage] CSI volumes should mount"},
+			wantComponent: "CSI",
+		},
+		{
+			name:          "component name breaks ties",
+			components:    []*Component{zebra, aardvark},
+			test:          v1.TestInfo{Name: "in-tree volumes should mount"},
+			wantComponent: "Aardvark",
+		},
+		{
+			name:          "component name breaks ties regardless of component order",
+			components:    []*Component{aardvark, zebra},
+			test:          v1.TestInfo{Name: "in-tree volumes should mount"},
+			wantComponent: "Aardvark",
+		},
+		{
+			name:       "no component claims the test",
+			components: []*Component{sigStorage, csi},
+			test:       v1.TestInfo{Name: "[sig-network] should work"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component, matcher := Resolve(tt.components, &tt.test)
+			if tt.wantComponent == "" {
+				if component != nil || matcher != nil {
+					t.Errorf("Resolve() = %q, want no owner", component.Name)
+				}
+				return
+			}
+			if component == nil || matcher == nil {
+				t.Fatalf("Resolve() found no owner, want %q", tt.wantComponent)
+			}
+			if component.Name != tt.wantComponent {
+				t.Errorf("Resolve() = %q, want %q", component.Name, tt.wantComponent)
+			}
+		})
+	}
+}
+
+func TestResolveCatchAll(t *testing.T) {
+	misc := &Component{
+		Name:     "Misc",
+		Matchers: []ComponentMatcher{{IncludeGlob: []string{"*"}, Priority: PriorityCatchAll}},
+	}
+	storage := &Component{
+		Name:     "Storage",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage"}},
+	}
+	cvo := &Component{
+		Name:     "Cluster Version Operator",
+		Matchers: []ComponentMatcher{{IncludeAll: []string{"upgrade"}, Priority: -10}},
+	}
+	etcd := &Component{
+		Name:                 "Etcd",
+		DefaultJiraComponent: "Etcd",
+		Namespaces:           []string{"openshift-etcd"},
+	}
+	components := []*Component{misc, storage, cvo, etcd}
+
+	tests := []struct {
+		name          string
+		test          v1.TestInfo
+		wantComponent string
+	}{
+		{
+			name:          "default priority beats catch-all",
+			test:          v1.TestInfo{Name: "[sig-storage] volumes should mount"},
+			wantComponent: "Storage",
+		},
+		{
+			name:          "negative priority beats lower catch-all",
+			test:          v1.TestInfo{Name: "cluster upgrade should succeed"},
+			wantComponent: "Cluster Version Operator",
+		},
+		{
+			name:          "namespace beats catch-all",
+			test:          v1.TestInfo{Name: "alert/KubePodNotReady should not be at or above info in ns/openshift-etcd"},
+			wantComponent: "Etcd",
+		},
+		{
+			name:          "catch-all wins when nothing else matches",
+			test:          v1.TestInfo{Name: "[sig-arch] should be unowned"},
+			wantComponent: "Misc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component, _ := Resolve(components, &tt.test)
+			if component == nil {
+				t.Fatalf("Resolve() returned no component")
+			}
+			if component.Name != tt.wantComponent {
+				t.Errorf("Resolve() = %q, want %q", component.Name, tt.wantComponent)
+			}
+		})
+	}
+}
+
+func TestResolveCustomMatchers(t *testing.T) {
+	// A procedural rule: tests with an odd OCP case number belong to the component.
+	oddCases := func(test *v1.TestInfo) *ComponentMatcher {
+		var id int
+		if _, err := fmt.Sscanf(test.Name, "OCP-%d:", &id); err != nil || id%2 == 0 {
+			return nil
+		}
+		return &ComponentMatcher{Capabilities: []string{"Odd"}, Priority: 1}
+	}
+	never := func(test *v1.TestInfo) *ComponentMatcher { return nil }
+
+	custom := &Component{
+		Name:           "Custom",
+		Matchers:       []ComponentMatcher{{IncludeAll: []string{"custom"}}},
+		CustomMatchers: []func(*v1.TestInfo) *ComponentMatcher{never, oddCases},
+	}
+	storage := &Component{
+		Name:     "Storage",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage"}},
+	}
+	components := []*Component{custom, storage}
+
+	tests := []struct {
+		name          string
+		test          v1.TestInfo
+		wantComponent string
+		wantSource    MatchSource
+		wantIndex     int
+	}{
+		{
+			name:          "declarative matchers are evaluated first",
+			test:          v1.TestInfo{Name: "OCP-1: custom test"},
+			wantComponent: "Custom",
+			wantSource:    MatchSourceMatcher,
+		},
+		{
+			name:          "custom matcher priority beats other components",
+			test:          v1.TestInfo{Name: "OCP-3: [sig-storage] volumes should mount"},
+			wantComponent: "Custom",
+			wantSource:    MatchSourceCustom,
+			wantIndex:     1,
+		},
+		{
+			name:          "custom matcher doesn't match",
+			test:          v1.TestInfo{Name: "OCP-4: [sig-storage] volumes should mount"},
+			wantComponent: "Storage",
+			wantSource:    MatchSourceMatcher,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component, _ := Resolve(components, &tt.test)
+			if component == nil || component.Name != tt.wantComponent {
+				t.Fatalf("Resolve() = %v, want %q", component, tt.wantComponent)
+			}
+			_, reason := component.FindMatchWithReason(&tt.test)
+			if reason.Source != tt.wantSource || reason.MatcherIndex != tt.wantIndex {
+				t.Errorf("FindMatchWithReason() reason = %+v, want source %q index %d", reason, tt.wantSource, tt.wantIndex)
+			}
+		})
+	}
+}
+
+func TestFindAllOwners(t *testing.T) {
+	storage := &Component{
+		Name:     "Storage",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage"}},
+	}
+	csi := &Component{
+		Name:     "CSI",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage", IncludeAny: []string{"CSI"}}},
+	}
+	snapshots := &Component{
+		Name:     "Snapshots",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"snapshot"}}},
+	}
+	override := &Component{
+		Name:     "Override",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"override"}, Priority: 1}},
+	}
+	components := []*Component{storage, snapshots, csi, override}
+
+	tests := []struct {
+		name string
+		test string
+		want []string
+	}{
+		{
+			name: "co-owners in resolve order",
+			test: "[sig-storage] CSI snapshot should be restored",
+			want: []string{"CSI", "Snapshots", "Storage"},
+		},
+		{
+			name: "only the highest priority tier",
+			test: "[sig-storage] CSI snapshot should be restored override",
+			want: []string{"Override"},
+		},
+		{
+			name: "no owners",
+			test: "[sig-arch] should be unowned",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &v1.TestInfo{Name: tt.test}
+			owners := FindAllOwners(components, test)
+			var got []string
+			for _, owner := range owners {
+				got = append(got, owner.Component.Name)
+				if owner.Source != MatchSourceMatcher {
+					t.Errorf("FindAllOwners() source of %s = %s, want %s", owner.Component.Name, owner.Source, MatchSourceMatcher)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAllOwners() = %v, want %v", got, tt.want)
+			}
+			if winner, _ := Resolve(components, test); len(owners) > 0 && winner != owners[0].Component {
+				t.Errorf("FindAllOwners()[0] = %s, want Resolve's owner %s", owners[0].Component.Name, winner.Name)
+			}
+		})
+	}
+}
+
+func TestOwnerForTestName(t *testing.T) {
+	storage := &Component{
+		Name:     "Storage",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage"}},
+	}
+	etcd := &Component{
+		Name:     "Etcd",
+		Matchers: []ComponentMatcher{{Suite: "ETCD", Capabilities: []string{"DR"}, Priority: 1}},
+	}
+	components := []*Component{storage, etcd}
+
+	component, matcher := OwnerForTestName(components, "[sig-storage] backups should restore", "ETCD")
+	if component != etcd {
+		t.Fatalf("OwnerForTestName() component = %v, want Etcd", component)
+	}
+	if !reflect.DeepEqual(*matcher, etcd.Matchers[0]) {
+		t.Errorf("OwnerForTestName() matcher = %+v, want %+v", *matcher, etcd.Matchers[0])
+	}
+
+	component, _ = OwnerForTestName(components, "[sig-storage] backups should restore", "")
+	if component != storage {
+		t.Errorf("OwnerForTestName() without suite = %v, want Storage", component)
+	}
+
+	if component, matcher := OwnerForTestName(components, "[sig-arch] should be unowned", ""); component != nil || matcher != nil {
+		t.Errorf("OwnerForTestName() = %v, %v, want no owner", component, matcher)
+	}
+}
+
+func TestDetectOverlaps(t *testing.T) {
+	storage := &Component{
+		Name:     "Storage",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage"}},
+	}
+	csi := &Component{
+		Name:     "CSI",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"CSI"}}},
+	}
+	csiPriority := &Component{
+		Name:     "CSI Drivers",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"csi-driver"}, Priority: 1}},
+	}
+	components := []*Component{storage, csi, csiPriority}
+
+	tests := []*v1.TestInfo{
+		{Name: "[sig-storage] CSI volumes should mount", Suite: "openshift-tests"},
+		{Name: "[sig-storage] CSI csi-driver volumes should mount"},
+		{Name: "[sig-storage] in-tree volumes should mount"},
+		{Name: "[sig-network] should work"},
+	}
+
+	want := []Overlap{
+		{
+			TestName:   "[sig-storage] CSI volumes should mount",
+			Suite:      "openshift-tests",
+			Components: []string{"CSI", "Storage"},
+			Priority:   0,
+		},
+	}
+	if got := DetectOverlaps(components, tests); !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectOverlaps() = %+v, want %+v", got, want)
+	}
+}
+
+func TestUnmatchedTests(t *testing.T) {
+	components := []*Component{
+		{
+			Name:     "Storage",
+			Matchers: []ComponentMatcher{{SIG: "sig-storage"}},
+		},
+		{
+			Name:                 "Networking",
+			DefaultJiraComponent: "Networking",
+			Namespaces:           []string{"openshift-multus"},
+		},
+	}
+
+	unowned := &v1.TestInfo{Name: "[sig-arch] should be unowned"}
+	unownedNamespace := &v1.TestInfo{Name: "alert/KubePodNotReady should not be at or above info in ns/openshift-etcd"}
+	tests := []*v1.TestInfo{
+		{Name: "[sig-storage] volumes should mount"},
+		unowned,
+		{Name: "alert/KubePodNotReady should not be at or above info in ns/openshift-multus"},
+		unownedNamespace,
+	}
+
+	want := []*v1.TestInfo{unowned, unownedNamespace}
+	if got := UnmatchedTests(components, tests); !reflect.DeepEqual(got, want) {
+		t.Errorf("UnmatchedTests() = %+v, want %+v", got, want)
+	}
+}
+
+func TestOwnedTests(t *testing.T) {
+	storage := &Component{
+		Name:     "Storage",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage"}},
+	}
+	csi := &Component{
+		Name:     "CSI",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"CSI"}, Priority: 1}},
+	}
+	etcd := &Component{
+		Name:     "Etcd",
+		Matchers: []ComponentMatcher{{SIG: "sig-etcd"}},
+	}
+
+	volumes := &v1.TestInfo{Name: "[sig-storage] volumes should mount"}
+	snapshots := &v1.TestInfo{Name: "[sig-storage] snapshots should restore"}
+	csiVolumes := &v1.TestInfo{Name: "[sig-storage] CSI volumes should mount"}
+	corpus := []*v1.TestInfo{
+		volumes,
+		csiVolumes,
+		{Name: "[sig-etcd] etcd should be healthy"},
+		snapshots,
+		{Name: "[sig-arch] should be unowned"},
+	}
+
+	tests := []struct {
+		name   string
+		target *Component
+		all    []*Component
+		want   []*v1.TestInfo
+	}{
+		{
+			name:   "test lost on priority is excluded",
+			target: storage,
+			all:    []*Component{storage, csi, etcd},
+			want:   []*v1.TestInfo{volumes, snapshots},
+		},
+		{
+			name:   "test won on priority is included",
+			target: csi,
+			all:    []*Component{storage, csi, etcd},
+			want:   []*v1.TestInfo{csiVolumes},
+		},
+		{
+			name:   "without competition the target owns every test it matches",
+			target: storage,
+			all:    []*Component{storage, etcd},
+			want:   []*v1.TestInfo{volumes, csiVolumes, snapshots},
+		},
+		{
+			name:   "target competes even if it isn't among all",
+			target: storage,
+			all:    []*Component{csi, etcd},
+			want:   []*v1.TestInfo{volumes, snapshots},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OwnedTests(tt.target, tt.all, corpus); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OwnedTests() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCoverage(t *testing.T) {
+	storage := &Component{
+		Name:     "Storage",
+		Matchers: []ComponentMatcher{{SIG: "sig-storage"}},
+	}
+	csi := &Component{
+		Name:     "CSI",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"CSI"}}},
+	}
+	csiPriority := &Component{
+		Name:     "CSI Drivers",
+		Matchers: []ComponentMatcher{{IncludeAny: []string{"csi-driver"}, Priority: 1}},
+	}
+	components := []*Component{storage, csi, csiPriority}
+
+	tests := []*v1.TestInfo{
+		{Name: "[sig-storage] CSI volumes should mount", Suite: "openshift-tests"},
+		{Name: "[sig-storage] CSI csi-driver volumes should mount"},
+		{Name: "[sig-storage] in-tree volumes should mount"},
+		{Name: "[sig-network] should work"},
+		{Name: "[sig-arch] should be unowned"},
+	}
+
+	unmatched, conflicts := ValidateCoverage(components, tests)
+	if want := []string{"[sig-network] should work", "[sig-arch] should be unowned"}; !reflect.DeepEqual(unmatched, want) {
+		t.Errorf("ValidateCoverage() unmatched = %q, want %q", unmatched, want)
+	}
+	want := []ConflictReport{
+		{
+			TestName:   "[sig-storage] CSI volumes should mount",
+			Suite:      "openshift-tests",
+			Components: []string{"CSI", "Storage"},
+			Priority:   0,
+		},
+	}
+	if !reflect.DeepEqual(conflicts, want) {
+		t.Errorf("ValidateCoverage() conflicts = %+v, want %+v", conflicts, want)
+	}
+
+	unmatched, conflicts = ValidateCoverage(components, tests[1:3])
+	if len(unmatched) != 0 || len(conflicts) != 0 {
+		t.Errorf("ValidateCoverage() = %q, %+v, want no problems", unmatched, conflicts)
+	}
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+// MatcherStat is the number of tests one of a component's matchers matched.
+type MatcherStat struct {
+	// Component and MatcherIndex identify the matcher by component name and its index
+	// in the component's Matchers.
+	Component    string
+	MatcherIndex int
+
+	// Matcher is a copy of the matcher, so reports can show its defining fields.
+	Matcher ComponentMatcher
+
+	// Hits is the number of tests in the corpus the matcher matched.
+	Hits int
+}
+
+// MatcherStats counts, for every matcher of every component, how many tests in the corpus
+// it matches. A matcher is counted whenever its conditions hold for a test, even if an
+// earlier matcher or another component ends up owning the test. Matchers with no hits are
+// included, as they're the ones likely to be stale. The result is sorted by component
+// name, then matcher index.
+func MatcherStats(components []*Component, tests []*v1.TestInfo) []MatcherStat {
+	var stats []MatcherStat
+	for _, c := range components {
+		matchers := c.EffectiveMatchers()
+		for i := range matchers {
+			stat := MatcherStat{
+				Component:    c.Name,
+				MatcherIndex: i,
+				Matcher:      matchers[i],
+			}
+			compiled := c.compiledMatcher(i)
+			for _, test := range tests {
+				if matchers[i].matches(c.matchingTest(test), compiled) {
+					stat.Hits++
+				}
+			}
+			stats = append(stats, stat)
+		}
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Component != stats[j].Component {
+			return stats[i].Component < stats[j].Component
+		}
+		return stats[i].MatcherIndex < stats[j].MatcherIndex
+	})
+	return stats
+}
+
+// UnusedMatchers returns the stats of the matchers that matched no tests.
+func UnusedMatchers(stats []MatcherStat) []MatcherStat {
+	var unused []MatcherStat
+	for _, stat := range stats {
+		if stat.Hits == 0 {
+			unused = append(unused, stat)
+		}
+	}
+	return unused
+}
+
+// ShadowReport describes a matcher that's unreachable in the corpus: every test it matches
+// is claimed by another of the component's matchers, because that matcher comes earlier
+// with the same priority, or has a higher one.
+type ShadowReport struct {
+	// Component and MatcherIndex identify the shadowed matcher.
+	Component    string
+	MatcherIndex int
+
+	// ShadowedBy are the indexes of the matchers that claimed the shadowed matcher's tests,
+	// sorted.
+	ShadowedBy []int
+
+	// Tests is the number of tests in the corpus the shadowed matcher matched.
+	Tests int
+}
+
+// FindShadowedMatchers reports the component's matchers that match tests in the corpus, but
+// never claim any of them, so they can be reordered or removed. Matchers that don't match
+// any tests aren't reported, see UnusedMatchers. Only the component's Matchers compete, the
+// other stages of FindMatch are ignored. The result is sorted by matcher index.
+func FindShadowedMatchers(c *Component, tests []*v1.TestInfo) []ShadowReport {
+	matchers := c.EffectiveMatchers()
+	matched := make([]int, len(matchers))
+	claimed := make([]bool, len(matchers))
+	shadowedBy := make([]sets.Set[int], len(matchers))
+	for _, test := range tests {
+		test = c.matchingTest(test)
+		winner, _ := c.findMatcher(test, "")
+		if winner < 0 {
+			continue
+		}
+		claimed[winner] = true
+		for i := range matchers {
+			if i == winner || !matchers[i].matches(test, c.compiledMatcher(i)) {
+				continue
+			}
+			matched[i]++
+			if shadowedBy[i] == nil {
+				shadowedBy[i] = sets.New[int]()
+			}
+			shadowedBy[i].Insert(winner)
+		}
+	}
+
+	var reports []ShadowReport
+	for i := range matchers {
+		if claimed[i] || matched[i] == 0 {
+			continue
+		}
+		reports = append(reports, ShadowReport{
+			Component:    c.Name,
+			MatcherIndex: i,
+			ShadowedBy:   sets.List(shadowedBy[i]),
+			Tests:        matched[i],
+		})
+	}
+	return reports
+}
+
+// CapabilityCounts resolves every test in the corpus to its owner, see Resolve, and counts
+// how many of the matched tests carry each capability, whether it's derived from the
+// test's operator or from the owner's matchers. Unmatched tests, and matched tests without
+// capabilities, aren't counted.
+func CapabilityCounts(components []*Component, tests []*v1.TestInfo) map[string]int {
+	counts := make(map[string]int)
+	for _, test := range tests {
+		_, m := Resolve(components, test)
+		if m == nil {
+			continue
+		}
+		for _, capability := range sets.List(sets.New(m.Capabilities...)) {
+			counts[capability]++
+		}
+	}
+	return counts
+}
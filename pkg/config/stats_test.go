@@ -0,0 +1,117 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestMatcherStats(t *testing.T) {
+	storage := ComponentMatcher{SIG: "sig-storage"}
+	csi := ComponentMatcher{IncludeAll: []string{"CSI"}}
+	stale := ComponentMatcher{IncludeAll: []string{"flexvolume"}}
+	etcd := ComponentMatcher{SIG: "sig-etcd"}
+	components := []*Component{
+		{
+			Name:     "Storage",
+			Matchers: []ComponentMatcher{storage, csi, stale},
+		},
+		{
+			Name:     "Etcd",
+			Matchers: []ComponentMatcher{etcd},
+		},
+	}
+	tests := []*v1.TestInfo{
+		{Name: "[sig-storage] CSI volumes should mount"},
+		{Name: "[sig-storage] volumes should mount"},
+		{Name: "[sig-etcd] etcd should be healthy"},
+		{Name: "[sig-arch] should be unowned"},
+	}
+
+	stats := MatcherStats(components, tests)
+	want := []MatcherStat{
+		{Component: "Etcd", MatcherIndex: 0, Matcher: etcd, Hits: 1},
+		{Component: "Storage", MatcherIndex: 0, Matcher: storage, Hits: 2},
+		{Component: "Storage", MatcherIndex: 1, Matcher: csi, Hits: 1},
+		{Component: "Storage", MatcherIndex: 2, Matcher: stale, Hits: 0},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("MatcherStats() = %+v, want %+v", stats, want)
+	}
+
+	wantUnused := []MatcherStat{
+		{Component: "Storage", MatcherIndex: 2, Matcher: stale, Hits: 0},
+	}
+	if got := UnusedMatchers(stats); !reflect.DeepEqual(got, wantUnused) {
+		t.Errorf("UnusedMatchers() = %+v, want %+v", got, wantUnused)
+	}
+}
+
+func TestFindShadowedMatchers(t *testing.T) {
+	c := &Component{
+		Name: "Etcd",
+		Matchers: []ComponentMatcher{
+			{SIG: "sig-etcd"},
+			{SIG: "sig-etcd", IncludeAll: []string{"backup"}},
+			{IncludeAll: []string{"restore"}},
+			{IncludeAll: []string{"quorum"}, Priority: -1},
+			{IncludeAll: []string{"defrag"}},
+			{IncludeAll: []string{"unused"}},
+		},
+	}
+	tests := []*v1.TestInfo{
+		{Name: "[sig-etcd] etcd backup should succeed"},
+		{Name: "[sig-etcd] etcd restore should succeed"},
+		{Name: "[sig-api-machinery] etcd restore should succeed"},
+		{Name: "[sig-etcd] etcd quorum guard should hold"},
+		{Name: "[sig-api-machinery] etcd defrag should hold quorum"},
+	}
+
+	// The specific backup matcher is always beaten by the earlier broad SIG matcher, and
+	// the low priority quorum matcher by the SIG and defrag matchers. The restore matcher
+	// also loses a test to the SIG matcher, but claims another.
+	want := []ShadowReport{
+		{Component: "Etcd", MatcherIndex: 1, ShadowedBy: []int{0}, Tests: 1},
+		{Component: "Etcd", MatcherIndex: 3, ShadowedBy: []int{0, 4}, Tests: 2},
+	}
+	if got := FindShadowedMatchers(c, tests); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindShadowedMatchers() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCapabilityCounts(t *testing.T) {
+	components := []*Component{
+		{
+			Name:                 "Etcd",
+			DefaultJiraComponent: "Etcd",
+			OperatorConfigs:      []OperatorConfig{{Name: "etcd", Capabilities: []string{"Operator"}}},
+			Matchers: []ComponentMatcher{
+				{IncludeAll: []string{"quorum"}, Capabilities: []string{"Quorum"}},
+				{IncludeAll: []string{"[sig-etcd]"}, Capabilities: []string{"Health", "Health"}},
+			},
+		},
+		{
+			Name:     "Storage",
+			Matchers: []ComponentMatcher{{SIG: "sig-storage", Capabilities: []string{"Health"}}},
+		},
+	}
+	for _, c := range components {
+		if err := c.Compile(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []*v1.TestInfo{
+		{Name: "Operator upgrade etcd"},
+		{Name: "[sig-etcd] cluster should be healthy"},
+		{Name: "[sig-etcd] quorum should be kept"},
+		{Name: "[sig-storage] volumes should mount"},
+		{Name: "[sig-arch] should be unowned"},
+	}
+
+	got := CapabilityCounts(components, tests)
+	want := map[string]int{"Operator": 1, "upgrade": 1, "Quorum": 1, "Health": 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CapabilityCounts() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/openshift-eng/ci-test-mapping/pkg/util"
+)
+
+const (
+	// maxSuggestedSubstrings caps the substrings SuggestMatcher picks.
+	maxSuggestedSubstrings = 5
+	// maxSuggestedWords is the longest run of words SuggestMatcher considers as a substring.
+	maxSuggestedWords = 4
+)
+
+// SuggestMatcher suggests a matcher for the target test name, with IncludeAll substrings
+// that distinguish it from the other names in the corpus. It's an authoring aid: the
+// candidates are the target's bracketed tags and runs of up to four of its other words,
+// and it greedily picks whichever excludes the most other names, preferring longer
+// substrings, until none remain or no candidate excludes more. The result is a starting
+// point to review, it's neither guaranteed to be minimal nor to be unique to the target,
+// e.g. when the corpus has a name containing the target's.
+func SuggestMatcher(target string, corpus []string) ComponentMatcher {
+	var remaining []string
+	for _, name := range corpus {
+		if name != target {
+			remaining = append(remaining, name)
+		}
+	}
+
+	candidates := suggestionCandidates(target)
+	var picked []string
+	for len(remaining) > 0 && len(picked) < maxSuggestedSubstrings {
+		best, bestExcluded := "", 0
+		for _, candidate := range candidates {
+			excluded := 0
+			for _, name := range remaining {
+				if !strings.Contains(name, candidate) {
+					excluded++
+				}
+			}
+			if excluded > bestExcluded || (excluded == bestExcluded && excluded > 0 && len(candidate) > len(best)) {
+				best, bestExcluded = candidate, excluded
+			}
+		}
+		if bestExcluded == 0 {
+			break
+		}
+
+		picked = append(picked, best)
+		kept := remaining[:0]
+		for _, name := range remaining {
+			if strings.Contains(name, best) {
+				kept = append(kept, name)
+			}
+		}
+		remaining = kept
+	}
+
+	if len(picked) == 0 && len(candidates) > 0 {
+		// Nothing distinguishes the target, e.g. an empty corpus, so suggest its longest
+		// candidate.
+		picked = append(picked, candidates[0])
+		for _, candidate := range candidates[1:] {
+			if len(candidate) > len(picked[0]) {
+				picked[0] = candidate
+			}
+		}
+	}
+	return ComponentMatcher{IncludeAll: picked}
+}
+
+// suggestionCandidates returns the substrings of the target SuggestMatcher picks from, in
+// the order they appear, without duplicates.
+func suggestionCandidates(target string) []string {
+	var candidates []string
+	seen := make(map[string]bool)
+	add := func(candidate string) {
+		if candidate != "" && !seen[candidate] {
+			seen[candidate] = true
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	for _, tag := range util.FindTags(target) {
+		add(tag)
+	}
+	words := strings.Fields(util.StripTags(target))
+	for i := range words {
+		for n := 1; n <= maxSuggestedWords && i+n <= len(words); n++ {
+			add(strings.Join(words[i:i+n], " "))
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestSuggestMatcher(t *testing.T) {
+	corpus := []string{
+		"[sig-network] pods should be ready [Suite:openshift/conformance/parallel]",
+		"[sig-network][Feature:EgressIP] egress IPs should be assigned [Suite:openshift/conformance/parallel]",
+		"[sig-network][Feature:EgressIP] egress IPs should be released [Suite:openshift/conformance/serial]",
+		"[sig-storage] CSI volumes should be released [Suite:openshift/conformance/parallel]",
+		"[sig-storage] pods should be ready [Suite:openshift/conformance/parallel]",
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		want   []string
+	}{
+		{
+			name:   "a single substring distinguishes the target",
+			target: corpus[2],
+			want:   []string{"[Suite:openshift/conformance/serial]"},
+		},
+		{
+			name:   "several substrings are needed",
+			target: corpus[0],
+			want:   []string{"pods should be ready", "[sig-network]"},
+		},
+		{
+			name:   "target not in the corpus",
+			target: "[sig-network][Feature:EgressIP] egress IPs should be failed over",
+			want:   []string{"should be failed over"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SuggestMatcher(tt.target, corpus)
+			if !reflect.DeepEqual(got.IncludeAll, tt.want) {
+				t.Errorf("SuggestMatcher() IncludeAll = %q, want %q", got.IncludeAll, tt.want)
+			}
+
+			// The suggestion matches the target, and none of the rest of the corpus.
+			c := &Component{Name: "Suggested", Matchers: []ComponentMatcher{got}}
+			if c.FindMatch(&v1.TestInfo{Name: tt.target}) == nil {
+				t.Errorf("SuggestMatcher() = %+v doesn't match the target", got)
+			}
+			for _, name := range corpus {
+				if name != tt.target && c.FindMatch(&v1.TestInfo{Name: name}) != nil {
+					t.Errorf("SuggestMatcher() = %+v also matches %q", got, name)
+				}
+			}
+		})
+	}
+
+	if got := SuggestMatcher("[sig-network] pods should be ready", nil); !reflect.DeepEqual(got.IncludeAll, []string{"pods should be ready"}) {
+		t.Errorf("SuggestMatcher() with no corpus IncludeAll = %q, want the longest candidate", got.IncludeAll)
+	}
+}
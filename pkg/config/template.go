@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MatcherTemplates are named matcher fragments that matchers can share by naming one in
+// their TemplateRef, e.g. a standard ExcludeAny list. Templates must be added before the
+// components referring to them are compiled, and mustn't refer to other templates.
+var MatcherTemplates = map[string]ComponentMatcher{}
+
+// resolveTemplate returns the matcher with its template's fields merged in, see TemplateRef.
+func (cm *ComponentMatcher) resolveTemplate() (ComponentMatcher, error) {
+	if cm.TemplateRef == "" {
+		return *cm, nil
+	}
+	template, ok := MatcherTemplates[cm.TemplateRef]
+	if !ok {
+		return *cm, fmt.Errorf("unknown matcher template %q", cm.TemplateRef)
+	}
+	if template.TemplateRef != "" {
+		return *cm, fmt.Errorf("matcher template %q refers to another template, %q", cm.TemplateRef, template.TemplateRef)
+	}
+	return applyTemplate(template, *cm), nil
+}
+
+// applyTemplate returns the matcher with the template's fields merged in: lists are the
+// union of the template's and the matcher's, Tags have the union of both's values for
+// each key, Params have both's parameters with the matcher's values taking precedence,
+// and other fields are the template's unless the matcher sets them to a non-zero value, so
+// a matcher can't override a template's field back to its zero value. Applying a template
+// more than once has no further effect.
+func applyTemplate(template, m ComponentMatcher) ComponentMatcher {
+	resolved := reflect.ValueOf(&m).Elem()
+	base := reflect.ValueOf(template)
+	for i := 0; i < resolved.NumField(); i++ {
+		field, from := resolved.Field(i), base.Field(i)
+		switch value := field.Interface().(type) {
+		case []string:
+			field.Set(reflect.ValueOf(unionStrings(from.Interface().([]string), value)))
+		case map[string][]string:
+			fromTags := from.Interface().(map[string][]string)
+			if len(fromTags) == 0 {
+				continue
+			}
+			tags := make(map[string][]string, len(fromTags)+len(value))
+			for key, values := range fromTags {
+				tags[key] = unionStrings(values, nil)
+			}
+			for key, values := range value {
+				tags[key] = unionStrings(tags[key], values)
+			}
+			field.Set(reflect.ValueOf(tags))
+		case map[string]string:
+			fromParams := from.Interface().(map[string]string)
+			if len(fromParams) == 0 {
+				continue
+			}
+			params := make(map[string]string, len(fromParams)+len(value))
+			for key, v := range fromParams {
+				params[key] = v
+			}
+			for key, v := range value {
+				params[key] = v
+			}
+			field.Set(reflect.ValueOf(params))
+		default:
+			if field.IsZero() {
+				field.Set(from)
+			}
+		}
+	}
+	return m
+}
+
+// resolveTemplates returns the component's Matchers with their templates merged in, or
+// Matchers itself if none of them refer to a template.
+func (c *Component) resolveTemplates() ([]ComponentMatcher, error) {
+	var resolved []ComponentMatcher
+	for i := range c.Matchers {
+		if c.Matchers[i].TemplateRef == "" {
+			continue
+		}
+		if resolved == nil {
+			resolved = append([]ComponentMatcher{}, c.Matchers...)
+		}
+		m, err := c.Matchers[i].resolveTemplate()
+		if err != nil {
+			return nil, fmt.Errorf("component %q matcher %d: %w", c.Name, i, err)
+		}
+		resolved[i] = m
+	}
+	if resolved == nil {
+		return c.Matchers, nil
+	}
+	return resolved, nil
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+)
+
+func TestComponent_CompileTemplates(t *testing.T) {
+	MatcherTemplates["standard-excludes"] = ComponentMatcher{
+		ExcludeAny:    []string{"[Disabled]", "[Flaky]"},
+		Tags:          map[string][]string{"Feature": {"Idling"}},
+		JiraComponent: "Networking / router",
+		Priority:      1,
+	}
+	MatcherTemplates["nested"] = ComponentMatcher{TemplateRef: "standard-excludes"}
+	defer func() {
+		delete(MatcherTemplates, "standard-excludes")
+		delete(MatcherTemplates, "nested")
+	}()
+
+	written := ComponentMatcher{
+		TemplateRef: "standard-excludes",
+		IncludeAll:  []string{"Unidling"},
+		ExcludeAny:  []string{"[Flaky]", "SCTP"},
+		Tags:        map[string][]string{"Feature": {"Other"}},
+		Priority:    2,
+	}
+	c := &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking",
+		Matchers:             []ComponentMatcher{written},
+	}
+	if err := c.Compile(); err != nil {
+		t.Fatalf("Compile() returned error: %v", err)
+	}
+
+	want := ComponentMatcher{
+		TemplateRef:   "standard-excludes",
+		IncludeAll:    []string{"Unidling"},
+		ExcludeAny:    []string{"[Disabled]", "[Flaky]", "SCTP"},
+		Tags:          map[string][]string{"Feature": {"Idling", "Other"}},
+		JiraComponent: "Networking / router",
+		Priority:      2,
+	}
+	if got := c.EffectiveMatchers()[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Compile() resolved the matcher to %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(c.Matchers[0], written) {
+		t.Errorf("Compile() changed the matcher to %+v, want it left as written", c.Matchers[0])
+	}
+	if err := c.Compile(); err != nil || !reflect.DeepEqual(c.EffectiveMatchers()[0], want) {
+		t.Errorf("Compile() again resolved the matcher to %+v, %v, want %+v", c.EffectiveMatchers()[0], err, want)
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "[sig-network][Feature:Idling][Feature:Other] Unidling should work", want: "Networking / router"},
+		{name: "[sig-network][Feature:Idling][Feature:Other] Unidling should work [Disabled]"},
+		{name: "[sig-network][Feature:Other] Unidling should work"},
+	}
+	for _, tt := range tests {
+		got := c.FindMatch(&v1.TestInfo{Name: tt.name})
+		if tt.want == "" {
+			if got != nil {
+				t.Errorf("FindMatch(%q) = %+v, want nil", tt.name, got)
+			}
+		} else if got == nil || got.JiraComponent != tt.want {
+			t.Errorf("FindMatch(%q) = %+v, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// Sorting the matchers keeps their templates.
+	sorted := &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking",
+		SortMatchers:         true,
+		Matchers: []ComponentMatcher{
+			{IncludeAll: []string{"foo"}},
+			{TemplateRef: "standard-excludes", IncludeAll: []string{"bar"}, Priority: 2},
+		},
+	}
+	if err := sorted.Compile(); err != nil {
+		t.Fatalf("Compile() returned error: %v", err)
+	}
+	if got := sorted.EffectiveMatchers()[0].ExcludeAny; !reflect.DeepEqual(got, []string{"[Disabled]", "[Flaky]"}) {
+		t.Errorf("Compile() with SortMatchers resolved excludeAny to %q, want the template's", got)
+	}
+	if got := sorted.FindMatch(&v1.TestInfo{Name: "[sig-network][Feature:Idling] bar [Flaky]"}); got != nil {
+		t.Errorf("FindMatch() = %+v, want the template's exclusion to apply", got)
+	}
+
+	for ref, wantErr := range map[string]string{
+		"missing": `component "Networking" matcher 0: unknown matcher template "missing"`,
+		"nested":  `component "Networking" matcher 0: matcher template "nested" refers to another template, "standard-excludes"`,
+	} {
+		c := &Component{Name: "Networking", DefaultJiraComponent: "Networking", Matchers: []ComponentMatcher{{TemplateRef: ref}}}
+		if err := c.Compile(); err == nil || err.Error() != wantErr {
+			t.Errorf("Compile() error = %v, want %s", err, wantErr)
+		}
+		if err := c.Validate(); err == nil || !strings.Contains(err.Error(), "matchers[0].templateRef") {
+			t.Errorf("Validate() error = %v, want a templateRef error", err)
+		}
+	}
+}
+
+func TestComponent_CompileTemplateChanged(t *testing.T) {
+	MatcherTemplates["router"] = ComponentMatcher{JiraComponent: "Networking / router", Priority: 1}
+	defer delete(MatcherTemplates, "router")
+
+	c := &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking",
+		Matchers:             []ComponentMatcher{{TemplateRef: "router", IncludeAll: []string{"route"}}},
+	}
+	if err := c.Compile(); err != nil {
+		t.Fatalf("Compile() returned error: %v", err)
+	}
+	// The matcher's unset, zero Priority doesn't override the template's.
+	if got := c.EffectiveMatchers()[0]; got.JiraComponent != "Networking / router" || got.Priority != 1 {
+		t.Errorf("Compile() resolved the matcher to %+v, want the template's Jira component and priority", got)
+	}
+	serialized, err := json.Marshal(c.Matchers[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"includeAll":["route"],"templateRef":"router"}`; string(serialized) != want {
+		t.Errorf("compiled matcher serializes to %s, want %s", serialized, want)
+	}
+
+	MatcherTemplates["router"] = ComponentMatcher{JiraComponent: "Networking / ingress"}
+	if err := c.Compile(); err != nil {
+		t.Fatalf("Compile() returned error: %v", err)
+	}
+	if got := c.FindMatch(&v1.TestInfo{Name: "route should work"}); got == nil || got.JiraComponent != "Networking / ingress" || got.Priority != 0 {
+		t.Errorf("FindMatch() = %+v after the template changed, want the new template's fields", got)
+	}
+}
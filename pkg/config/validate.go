@@ -0,0 +1,258 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ValidationErrors is the list of every problem found validating a component.
+type ValidationErrors []error
+
+// ConfigError is a problem with a component's configuration.
+type ConfigError struct {
+	// Path is the file the component was loaded from, and Line the line of the problem in
+	// it, if known.
+	Path string
+	Line int
+
+	// Component is the name of the component, if known.
+	Component string
+
+	// Field is the path to the offending field, as serialized, e.g. matchers[1].variants[0].
+	// It's empty for problems with the component as a whole.
+	Field string
+
+	// Reason describes the problem.
+	Reason string
+}
+
+func (e *ConfigError) Error() string {
+	var location []string
+	if e.Path != "" {
+		path := e.Path
+		if e.Line > 0 {
+			path = fmt.Sprintf("%s:%d", path, e.Line)
+		}
+		location = append(location, path+":")
+	}
+	if e.Component != "" {
+		location = append(location, fmt.Sprintf("component %q", e.Component))
+	}
+	if e.Field != "" {
+		location = append(location, e.Field)
+	}
+	if len(location) == 0 {
+		return e.Reason
+	}
+	return strings.TrimSuffix(strings.Join(location, " "), ":") + ": " + e.Reason
+}
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate checks the component's configuration, and returns a ValidationErrors
+// listing every problem found as a *ConfigError, or nil if there are none.
+func (c *Component) Validate() error {
+	var errs ValidationErrors
+	fieldError := func(err error, format string, args ...interface{}) {
+		errs = append(errs, &ConfigError{
+			Component: c.Name,
+			Field:     fmt.Sprintf(format, args...),
+			Reason:    err.Error(),
+		})
+	}
+
+	for i, variant := range c.Variants {
+		if err := validateVariant(variant); err != nil {
+			fieldError(err, "variants[%d]", i)
+		}
+	}
+
+	// Report each rename cycle once, rather than once per name leading into it.
+	inCycle := sets.New[string]()
+	for _, name := range sets.List(sets.KeySet(c.TestRenames)) {
+		if inCycle.Has(name) {
+			continue
+		}
+		if _, _, err := c.followRenames(name); err != nil {
+			fieldError(err, "testRenames[%q]", name)
+			for next := name; !inCycle.Has(next); next = c.TestRenames[next] {
+				inCycle.Insert(next)
+			}
+		}
+	}
+
+	for i, alias := range c.Aliases {
+		if alias == "" {
+			fieldError(fmt.Errorf("empty alias"), "aliases[%d]", i)
+		} else if strings.EqualFold(alias, c.DefaultJiraComponent) {
+			fieldError(fmt.Errorf("alias %q is the default jira component", alias), "aliases[%d]", i)
+		}
+	}
+
+	for i, rule := range c.TestRenameRules {
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			fieldError(err, "testRenameRules[%d].pattern", i)
+		}
+	}
+
+	for i, pattern := range c.NamespacePatterns {
+		if _, err := compileNamespacePattern(pattern); err != nil {
+			fieldError(err, "namespacePatterns[%d]", i)
+		}
+	}
+
+	if c.JiraComponentRegex != "" {
+		if _, err := compileJiraComponentRegex(c.JiraComponentRegex); err != nil {
+			fieldError(err, "jiraComponentRegex")
+		}
+	}
+
+	for i, config := range c.OperatorConfigs {
+		if config.Name == "" {
+			fieldError(fmt.Errorf("no operator name"), "operatorConfigs[%d].name", i)
+		}
+	}
+
+	for _, operator := range sets.List(sets.KeySet(c.OperatorTestPatterns)) {
+		if _, err := compileOperatorTestPatterns(c.OperatorTestPatterns[operator]); err != nil {
+			fieldError(err, "operatorTestPatterns[%q]", operator)
+		}
+	}
+
+	for i := range c.Matchers {
+		m := &c.Matchers[i]
+		for _, err := range m.validate(c) {
+			field := fmt.Sprintf("matchers[%d]", i)
+			if err.field != "" {
+				field += "." + err.field
+			}
+			fieldError(err.err, "%s", field)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidateAll checks every component's configuration, see Validate, along with the sanity
+// of the components as a whole: component names must be unique, matcher priorities must be
+// within the priority bands, PriorityCatchAll to PriorityForce, and no two components may
+// own the same namespace or SIG at the same priority, as neither would decide the owner.
+// It returns a ValidationErrors listing every problem found as a *ConfigError, or nil if
+// there are none, e.g. for failing fast when the components are loaded.
+func ValidateAll(components []*Component) error {
+	// ownership is a namespace or SIG owned at a priority.
+	type ownership struct {
+		name     string
+		priority int
+	}
+
+	var errs ValidationErrors
+	names := make(map[string]bool, len(components))
+	namespaceOwners := make(map[ownership]*Component)
+	sigOwners := make(map[ownership]*Component)
+	for _, c := range components {
+		fieldError := func(field, format string, args ...interface{}) {
+			errs = append(errs, &ConfigError{
+				Component: c.Name,
+				Field:     field,
+				Reason:    fmt.Sprintf(format, args...),
+			})
+		}
+
+		var validationErrs ValidationErrors
+		if err := c.Validate(); errors.As(err, &validationErrs) {
+			errs = append(errs, validationErrs...)
+		}
+
+		if names[c.Name] {
+			fieldError("name", "another component has the same name")
+		}
+		names[c.Name] = true
+
+		for i := range c.Matchers {
+			if p := c.Matchers[i].Priority; p < PriorityCatchAll || p > PriorityForce {
+				fieldError(fmt.Sprintf("matchers[%d].priority", i), "priority %d is outside the priority bands, %d to %d", p, PriorityCatchAll, PriorityForce)
+			}
+		}
+
+		for i, namespace := range c.Namespaces {
+			key := ownership{name: namespace, priority: c.namespacePriority()}
+			if owner, ok := namespaceOwners[key]; ok && owner != c {
+				fieldError(fmt.Sprintf("namespaces[%d]", i), "namespace %q is also owned by component %q, at the same priority %d", namespace, owner.Name, key.priority)
+				continue
+			}
+			namespaceOwners[key] = c
+		}
+
+		for i, sig := range c.OwnsSIG {
+			key := ownership{name: sig, priority: c.OwnsSIGPriority}
+			if owner, ok := sigOwners[key]; ok && owner != c {
+				fieldError(fmt.Sprintf("ownsSIG[%d]", i), "sig %q is also owned by component %q, at the same priority %d", sig, owner.Name, key.priority)
+				continue
+			}
+			sigOwners[key] = c
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// matcherError is a problem with a matcher's field, relative to the matcher.
+type matcherError struct {
+	field string
+	err   error
+}
+
+func (cm *ComponentMatcher) validate(c *Component) []matcherError {
+	var errs []matcherError
+
+	// The matcher is checked along with the fields it takes from its template.
+	resolved, err := cm.resolveTemplate()
+	if err != nil {
+		errs = append(errs, matcherError{"templateRef", err})
+	}
+	cm = &resolved
+
+	if cm.JiraComponent == "" && c.DefaultJiraComponent == "" {
+		errs = append(errs, matcherError{"jiraComponent", fmt.Errorf("no jira component, and the component has no default jira component")})
+	}
+
+	for i, variant := range cm.Variants {
+		if err := validateVariant(variant); err != nil {
+			errs = append(errs, matcherError{fmt.Sprintf("variants[%d]", i), err})
+		}
+	}
+
+	errs = append(errs, cm.validateEffectiveRange()...)
+
+	if _, err := cm.compile(); err != nil {
+		errs = append(errs, matcherError{"", err})
+	}
+
+	return errs
+}
+
+// validateVariant checks a variant is formatted as variantCategory:variantValue.
+func validateVariant(variant string) error {
+	parts := strings.SplitN(variant, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("variant %q is not formatted as variantCategory:variantValue", variant)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestComponent_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		component  Component
+		wantErrors []string
+	}{
+		{
+			name: "valid component",
+			component: Component{
+				Name:                 "Networking",
+				DefaultJiraComponent: "Networking",
+				Variants:             []string{"network:ovn", "platform:aws"},
+				Matchers: []ComponentMatcher{
+					{SIG: "sig-network", Variants: []string{"platform:aws"}},
+					{IncludeGlob: []string{"*ovn*"}},
+				},
+			},
+		},
+		{
+			name: "reports every problem",
+			component: Component{
+				Name:     "Networking",
+				Variants: []string{"platform-aws", ":aws", "network:"},
+				Matchers: []ComponentMatcher{
+					{SIG: "sig-network", JiraComponent: "Networking / ovn-kubernetes"},
+					{SIG: "sig-network", Variants: []string{"ovn"}},
+					{IncludeGlob: []string{"*[ovn"}, JiraComponent: "Networking / ovn-kubernetes"},
+				},
+			},
+			wantErrors: []string{
+				`component "Networking" variants[0]: variant "platform-aws" is not formatted as variantCategory:variantValue`,
+				`component "Networking" variants[1]: variant ":aws" is not formatted as variantCategory:variantValue`,
+				`component "Networking" variants[2]: variant "network:" is not formatted as variantCategory:variantValue`,
+				`component "Networking" matchers[1].jiraComponent: no jira component, and the component has no default jira component`,
+				`component "Networking" matchers[1].variants[0]: variant "ovn" is not formatted as variantCategory:variantValue`,
+				`component "Networking" matchers[2]: glob "*[ovn": unterminated character class`,
+			},
+		},
+		{
+			name: "invalid aliases",
+			component: Component{
+				Name:                 "Networking",
+				DefaultJiraComponent: "Networking",
+				Aliases:              []string{"Networking / SDN", "", "networking"},
+			},
+			wantErrors: []string{
+				`component "Networking" aliases[1]: empty alias`,
+				`component "Networking" aliases[2]: alias "networking" is the default jira component`,
+			},
+		},
+		{
+			name: "invalid effective range",
+			component: Component{
+				Name:                 "Networking",
+				DefaultJiraComponent: "Networking",
+				Matchers: []ComponentMatcher{
+					{SIG: "sig-network", EffectiveFrom: "4.15", EffectiveUntil: "4.14"},
+					{SIG: "sig-network", EffectiveFrom: "4.x", EffectiveUntil: "v5"},
+				},
+			},
+			wantErrors: []string{
+				`component "Networking" matchers[0].effectiveUntil: release "4.14" is not after effectiveFrom "4.15"`,
+				`component "Networking" matchers[1].effectiveFrom: release "4.x" is not formatted as dot-separated numbers, e.g. 4.14`,
+				`component "Networking" matchers[1].effectiveUntil: release "v5" is not formatted as dot-separated numbers, e.g. 4.14`,
+			},
+		},
+		{
+			name: "invalid operator test pattern",
+			component: Component{
+				Name:                 "Networking",
+				DefaultJiraComponent: "Networking",
+				OperatorTestPatterns: map[string][]string{
+					"network": {`network operator (?P<Capability>install`},
+				},
+			},
+			wantErrors: []string{
+				"component \"Networking\" operatorTestPatterns[\"network\"]: error parsing regexp: missing closing ): `network operator (?P<Capability>install`",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.component.Validate()
+			if len(tt.wantErrors) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() returned unexpected error: %v", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate() returned %v, want ValidationErrors", err)
+			}
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantErrors, "\n") {
+				t.Errorf("Validate() errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.wantErrors, "\n"))
+			}
+		})
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	networking := &Component{
+		Name:                 "Networking",
+		DefaultJiraComponent: "Networking",
+		Namespaces:           []string{"openshift-multus", "openshift-sdn"},
+		OwnsSIG:              []string{"sig-network"},
+	}
+	router := &Component{
+		Name:                 "Router",
+		DefaultJiraComponent: "Networking / router",
+		Namespaces:           []string{"openshift-ingress"},
+		OwnsSIG:              []string{"sig-network-edge"},
+		Matchers:             []ComponentMatcher{{SIG: "sig-network-edge", Priority: PriorityOverride}},
+	}
+	if err := ValidateAll([]*Component{networking, router}); err != nil {
+		t.Fatalf("ValidateAll() returned unexpected error: %v", err)
+	}
+
+	invalid := []*Component{
+		networking,
+		{
+			Name:              "OVN",
+			Namespaces:        []string{"openshift-ingress", "openshift-multus"},
+			NamespacePriority: PriorityOverride,
+			OwnsSIG:           []string{"sig-network"},
+			Matchers:          []ComponentMatcher{{SIG: "sig-network", Priority: 1000}},
+		},
+		{
+			Name:                 "Networking",
+			DefaultJiraComponent: "Networking",
+			Namespaces:           []string{"openshift-sdn"},
+		},
+		router,
+	}
+	wantErrors := []string{
+		`component "OVN" matchers[0].jiraComponent: no jira component, and the component has no default jira component`,
+		`component "OVN" matchers[0].priority: priority 1000 is outside the priority bands, -100 to 100`,
+		`component "OVN" ownsSIG[0]: sig "sig-network" is also owned by component "Networking", at the same priority 0`,
+		`component "Networking" name: another component has the same name`,
+		`component "Networking" namespaces[0]: namespace "openshift-sdn" is also owned by component "Networking", at the same priority 10`,
+	}
+	var errs ValidationErrors
+	if err := ValidateAll(invalid); !errors.As(err, &errs) {
+		t.Fatalf("ValidateAll() returned %v, want ValidationErrors", err)
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Error())
+	}
+	if strings.Join(got, "\n") != strings.Join(wantErrors, "\n") {
+		t.Errorf("ValidateAll() errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(wantErrors, "\n"))
+	}
+}
@@ -1,6 +1,8 @@
 package registry
 
 import (
+	"fmt"
+
 	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
 	"github.com/openshift-eng/ci-test-mapping/pkg/components/apiserverauth"
 	"github.com/openshift-eng/ci-test-mapping/pkg/components/awsloadbalanceroperator"
@@ -410,14 +412,29 @@ func NewComponentRegistry() *Registry {
 	return &r
 }
 
+// compiler is implemented by components that precompile their matchers, such as
+// those built on config.Component.
+type compiler interface {
+	Compile() error
+}
+
 func (r *Registry) Register(name string, component v1.Component) {
 	if r.Components == nil {
 		r.Components = make(map[string]v1.Component)
 	}
 
+	if c, ok := component.(compiler); ok {
+		if err := c.Compile(); err != nil {
+			panic(fmt.Sprintf("component %q could not be compiled: %v", name, err))
+		}
+	}
+
 	r.Components[name] = component
 }
 
+// GetForJiraComponent returns the component assigning the Jira component, or nil if there's
+// none. If no component assigns it, components that have a HasJiraComponent method, such as
+// those built on config.Component, are also found by their Jira component's former names.
 func (r *Registry) GetForJiraComponent(name string) v1.Component {
 	for _, c := range r.Components {
 		for _, j := range c.JiraComponents() {
@@ -427,9 +444,21 @@ func (r *Registry) GetForJiraComponent(name string) v1.Component {
 		}
 	}
 
+	for _, c := range r.Components {
+		if aliased, ok := c.(jiraComponentHaver); ok && aliased.HasJiraComponent(name) {
+			return c
+		}
+	}
+
 	return nil
 }
 
+// jiraComponentHaver is implemented by components that can be looked up by Jira component
+// aliases, see GetForJiraComponent.
+type jiraComponentHaver interface {
+	HasJiraComponent(name string) bool
+}
+
 func (r *Registry) Deregister(name string) {
 	delete(r.Components, name)
 }
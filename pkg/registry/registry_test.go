@@ -0,0 +1,98 @@
+package registry
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
+	"github.com/openshift-eng/ci-test-mapping/pkg/config"
+)
+
+// TestHighestPriorityMatcher checks, over the test corpora, which tests a component's
+// matchers claim differently now that the highest-priority matching matcher wins rather
+// than the first. Each difference in audited has been reviewed; any other fails.
+func TestHighestPriorityMatcher(t *testing.T) {
+	type difference struct {
+		component      string
+		first, claimed int
+	}
+	audited := map[difference]bool{
+		// "OTA cvo" tests also mention upgrades, which the -10 matcher leaves to others.
+		{component: "Cluster Version Operator", first: 1, claimed: 3}: true,
+		// ":Etcd " QE tests outrank the cluster-etcd-operator substring.
+		{component: "Etcd", first: 3, claimed: 4}: true,
+		// API_Server suite tests outrank the -1 sig-api-machinery catch-all.
+		{component: "kube-apiserver", first: 3, claimed: 4}: true,
+	}
+
+	var tests []v1.TestInfo
+	for _, dataset := range []string{"ci_analysis_us", "ci_analysis_qe"} {
+		content, err := os.ReadFile("../../data/openshift-gce-devel/" + dataset + "/junit.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var datasetTests []v1.TestInfo
+		if err := json.Unmarshal(content, &datasetTests); err != nil {
+			t.Fatal(err)
+		}
+		tests = append(tests, datasetTests...)
+	}
+
+	r := NewComponentRegistry()
+	names := make([]string, 0, len(r.Components))
+	for name := range r.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		c := configComponent(r.Components[name])
+		if c == nil {
+			continue
+		}
+		for i := range tests {
+			test := &tests[i]
+			_, reason := c.FindMatchWithReason(test)
+			if reason.Source != config.MatchSourceMatcher {
+				continue
+			}
+			first := firstMatcher(c, test)
+			if first != reason.MatcherIndex && !audited[difference{component: name, first: first, claimed: reason.MatcherIndex}] {
+				t.Errorf("%s: test %q is claimed by matchers[%d], but matchers[%d] is listed first", name, test.Name, reason.MatcherIndex, first)
+			}
+		}
+	}
+}
+
+// configComponent returns the config.Component the registered component is built on, or
+// nil if it isn't.
+func configComponent(component v1.Component) *config.Component {
+	v := reflect.Indirect(reflect.ValueOf(component))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	field := v.FieldByName("Component")
+	if !field.IsValid() {
+		return nil
+	}
+	c, _ := field.Interface().(*config.Component)
+	return c
+}
+
+// firstMatcher returns the index of the first of the component's matchers that matches the
+// test, which is the one that claimed it before priorities were compared.
+func firstMatcher(c *config.Component, test *v1.TestInfo) int {
+	failed := map[int]bool{}
+	for _, failure := range config.ExplainNonMatch(c, test) {
+		failed[failure.MatcherIndex] = true
+	}
+	for i := range c.EffectiveMatchers() {
+		if !failed[i] {
+			return i
+		}
+	}
+	return -1
+}
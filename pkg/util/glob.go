@@ -0,0 +1,107 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CompileGlob converts a shell-style glob pattern into a regular expression that
+// matches the whole input. A '*' matches any sequence of characters, '?' matches
+// any single character, '[abc]' or '[a-z]' match any character in the set, '[!abc]'
+// (or '[^abc]') match any character not in the set, and '\x' matches x literally.
+// Unlike path.Match, '*' and '?' also match the '/' character, as test names
+// frequently contain them.
+func CompileGlob(pattern string) (*regexp.Regexp, error) {
+	return compileGlob(pattern, false)
+}
+
+// CompilePathGlob is like CompileGlob, for doublestar-style globs matched against file
+// paths: '*' and '?' don't match the '/' character, so they stay within a path segment,
+// while a '**' segment matches any number of segments, including none. For example,
+// "test/e2e/storage/**" matches every file below test/e2e/storage, and "**/*_test.go"
+// matches test files in any directory.
+func CompilePathGlob(pattern string) (*regexp.Regexp, error) {
+	return compileGlob(pattern, true)
+}
+
+func compileGlob(pattern string, path bool) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString(`(?s)^`)
+
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; ch {
+		case '*':
+			if !path {
+				sb.WriteString(`.*`)
+				continue
+			}
+			segment := strings.HasPrefix(pattern[i:], "**") &&
+				(i == 0 || pattern[i-1] == '/') &&
+				(i+2 == len(pattern) || pattern[i+2] == '/')
+			if !segment {
+				sb.WriteString(`[^/]*`)
+				continue
+			}
+			// A ** segment: "a/**/b" also matches "a/b", and a trailing "a/**" matches
+			// everything below a.
+			if i+2 < len(pattern) {
+				sb.WriteString(`(?:.*/)?`)
+				i += 2
+			} else {
+				sb.WriteString(`.*`)
+				i++
+			}
+		case '?':
+			if path {
+				sb.WriteString(`[^/]`)
+			} else {
+				sb.WriteString(`.`)
+			}
+		case '\\':
+			if i+1 >= len(pattern) {
+				return nil, fmt.Errorf("glob %q: trailing escape character", pattern)
+			}
+			i++
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case '[':
+			j := i + 1
+			negate := j < len(pattern) && (pattern[j] == '!' || pattern[j] == '^')
+			if negate {
+				j++
+			}
+			// A ']' immediately after the opening bracket is part of the set, e.g. []a]
+			classStart := j
+			if j < len(pattern) && pattern[j] == ']' {
+				j++
+			}
+			end := strings.IndexByte(pattern[j:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("glob %q: unterminated character class", pattern)
+			}
+			class := pattern[classStart : j+end]
+			i = j + end
+
+			sb.WriteByte('[')
+			if negate {
+				sb.WriteByte('^')
+			}
+			for _, r := range class {
+				if r == '\\' || r == '[' || r == ']' || r == '^' {
+					sb.WriteByte('\\')
+				}
+				sb.WriteRune(r)
+			}
+			sb.WriteByte(']')
+		default:
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	sb.WriteString(`$`)
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("glob %q: %w", pattern, err)
+	}
+	return re, nil
+}
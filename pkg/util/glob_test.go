@@ -0,0 +1,138 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestCompileGlob(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		input     string
+		wantMatch bool
+		wantError bool
+	}{
+		{
+			name:      "star matches substring",
+			pattern:   "*oc adm must-gather*",
+			input:     "[sig-cli] oc adm must-gather runs successfully [Suite:openshift/conformance/parallel]",
+			wantMatch: true,
+		},
+		{
+			name:      "star matches slashes",
+			pattern:   "*ns/openshift-*",
+			input:     "alert/KubePodNotReady should not be at or above info in ns/openshift-etcd",
+			wantMatch: true,
+		},
+		{
+			name:      "pattern is anchored",
+			pattern:   "oc adm must-gather*",
+			input:     "[sig-cli] oc adm must-gather runs successfully",
+			wantMatch: false,
+		},
+		{
+			name:      "question mark matches one character",
+			pattern:   "*sig-?li*",
+			input:     "[sig-cli] oc adm must-gather runs successfully",
+			wantMatch: true,
+		},
+		{
+			name:      "question mark does not match zero characters",
+			pattern:   "*sig-?cli*",
+			input:     "[sig-cli] oc adm must-gather runs successfully",
+			wantMatch: false,
+		},
+		{
+			name:      "escaped brackets match literally",
+			pattern:   `\[sig-?li\]*`,
+			input:     "[sig-cli] oc adm must-gather runs successfully",
+			wantMatch: true,
+		},
+		{
+			name:      "character class",
+			pattern:   "*IPv[46]*",
+			input:     "[sig-network] should work over IPv6",
+			wantMatch: true,
+		},
+		{
+			name:      "negated character class",
+			pattern:   "*IPv[!46]*",
+			input:     "[sig-network] should work over IPv6",
+			wantMatch: false,
+		},
+		{
+			name:      "character range",
+			pattern:   "*[0-9] pods",
+			input:     "should scale to 100 pods",
+			wantMatch: true,
+		},
+		{
+			name:      "regex metacharacters are literal",
+			pattern:   "a.b(c)+",
+			input:     "aXb(c)+",
+			wantMatch: false,
+		},
+		{
+			name:      "unterminated character class",
+			pattern:   "*[abc",
+			wantError: true,
+		},
+		{
+			name:      "invalid character range",
+			pattern:   "[sig-?li]*",
+			wantError: true,
+		},
+		{
+			name:      "trailing escape",
+			pattern:   `abc\`,
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := CompileGlob(tt.pattern)
+			if tt.wantError {
+				if err == nil {
+					t.Fatalf("CompileGlob() did not return expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CompileGlob() returned unexpected error: %+v", err)
+			}
+			if got := re.MatchString(tt.input); got != tt.wantMatch {
+				t.Errorf("CompileGlob(%q).MatchString(%q) = %v, want %v", tt.pattern, tt.input, got, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestCompilePathGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    bool
+	}{
+		{pattern: "test/e2e/storage/**", input: "test/e2e/storage/csi/snapshot.go", want: true},
+		{pattern: "test/e2e/storage/**", input: "test/e2e/storage/volumes.go", want: true},
+		{pattern: "test/e2e/storage/**", input: "test/e2e/storagex/volumes.go", want: false},
+		{pattern: "test/**/csi/*.go", input: "test/csi/driver.go", want: true},
+		{pattern: "test/**/csi/*.go", input: "test/e2e/storage/csi/driver.go", want: true},
+		{pattern: "test/**/csi/*.go", input: "test/e2e/storage/csi/testsuites/driver.go", want: false},
+		{pattern: "**/*_test.go", input: "pkg/util/glob_test.go", want: true},
+		{pattern: "**/*_test.go", input: "glob_test.go", want: true},
+		{pattern: "test/e2e/*.go", input: "test/e2e/storage/volumes.go", want: false},
+		{pattern: "test/e2e/?.go", input: "test/e2e/a.go", want: true},
+		{pattern: "test/e2e/**.go", input: "test/e2e/storage/volumes.go", want: false},
+		{pattern: "test/e2e/**.go", input: "test/e2e/volumes.go", want: true},
+	}
+	for _, tt := range tests {
+		re, err := CompilePathGlob(tt.pattern)
+		if err != nil {
+			t.Fatalf("CompilePathGlob(%q) returned unexpected error: %v", tt.pattern, err)
+		}
+		if got := re.MatchString(tt.input); got != tt.want {
+			t.Errorf("CompilePathGlob(%q).MatchString(%q) = %v, want %v", tt.pattern, tt.input, got, tt.want)
+		}
+	}
+}
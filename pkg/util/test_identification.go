@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
 
 	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
 )
@@ -45,14 +46,63 @@ func DefaultCapabilities(test *v1.TestInfo) []string {
 	return capabilities
 }
 
+// IsSigTest reports whether the test name carries the [sigName] tag. That's usually the
+// test's SIG, see ExtractSIG, but tests may carry several SIG tags, and any of them count.
 func IsSigTest(testName, sigName string) bool {
 	return strings.Contains(testName, fmt.Sprintf("[%s]", sigName))
 }
 
+// ExtractSIG returns the SIG of the test, the first [sig-...] tag in its name without the
+// brackets, e.g. "sig-network" for "[sig-network][Feature:EgressIP] egress should work",
+// and whether it has one.
+func ExtractSIG(testName string) (string, bool) {
+	const prefix = "[sig-"
+	for rest := testName; ; {
+		start := strings.Index(rest, prefix)
+		if start < 0 {
+			return "", false
+		}
+		rest = rest[start+1:]
+		end := strings.IndexFunc(rest, func(r rune) bool { return r == ']' || unicode.IsSpace(r) })
+		if end >= len(prefix) && rest[end] == ']' {
+			return rest[:end], true
+		}
+	}
+}
+
+// HasSIGTag reports whether the test name carries any [sig-...] tag, e.g. [sig-network].
+func HasSIGTag(testName string) bool {
+	_, ok := ExtractSIG(testName)
+	return ok
+}
+
+// IsDisruptionTest reports whether the test follows one of the standard disruption test
+// namings, e.g. "disruption/kube-api connection/new should be available throughout the test".
 func IsDisruptionTest(testName string) bool {
 	return disruptionRegex.MatchString(testName)
 }
 
+// IsSerialTest reports whether the test runs serially: its name carries the [Serial] tag,
+// or its suite, either the test's Suite or a [Suite:...] tag, has a serial segment, e.g.
+// openshift/conformance/serial or openshift-tests-serial.
+func IsSerialTest(test *v1.TestInfo) bool {
+	tags := ExtractTestTags(test.Name)
+	if _, ok := tags["Serial"]; ok {
+		return true
+	}
+	for _, suite := range append([]string{test.Suite}, tags["Suite"]...) {
+		segments := strings.FieldsFunc(strings.ToLower(suite), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, segment := range segments {
+			if segment == "serial" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func IdentifyOperatorTest(operator, testName string) (isOperatorTest bool, capabilities []string) {
 	if matchOne(conditions, testName, operator) {
 		return true, []string{"operator-conditions"}
@@ -3,6 +3,8 @@ package util
 import (
 	"reflect"
 	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
 )
 
 func TestIdentifyOperatorTest(t *testing.T) {
@@ -68,3 +70,140 @@ func TestIdentifyOperatorTest(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractSIG(t *testing.T) {
+	tests := []struct {
+		testName string
+		want     string
+		wantOK   bool
+	}{
+		{testName: "[sig-network] services should work", want: "sig-network", wantOK: true},
+		{testName: "[Feature:Idling][sig-network-edge] Unidling should work", want: "sig-network-edge", wantOK: true},
+		{testName: "[sig-arch][sig-etcd] etcd should be healthy", want: "sig-arch", wantOK: true},
+		{testName: "[sig-] is empty [sig-cli] oc works", want: "sig-cli", wantOK: true},
+		{testName: "[sig-not a tag] [sig-cli] oc works", want: "sig-cli", wantOK: true},
+		{testName: "[bz-etcd][invariant] alert/etcdMembersDown should not be at or above info"},
+		{testName: "[sig-unterminated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got, ok := ExtractSIG(tt.testName); got != tt.want || ok != tt.wantOK {
+				t.Errorf("ExtractSIG() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestIsSigTest(t *testing.T) {
+	testName := "[sig-arch][sig-etcd][bz-etcd] etcd should be healthy"
+	for _, sig := range []string{"sig-arch", "sig-etcd", "bz-etcd"} {
+		if !IsSigTest(testName, sig) {
+			t.Errorf("IsSigTest(%q) = false, want true", sig)
+		}
+	}
+	if IsSigTest(testName, "sig-network") {
+		t.Errorf("IsSigTest(%q) = true, want false", "sig-network")
+	}
+}
+
+func TestHasSIGTag(t *testing.T) {
+	tests := []struct {
+		testName string
+		want     bool
+	}{
+		{testName: "[sig-network] services should work", want: true},
+		{testName: "[Feature:Idling][sig-network-edge] Unidling should work", want: true},
+		{testName: "[bz-etcd][invariant] alert/etcdMembersDown should not be at or above info", want: false},
+		{testName: "operator install etcd", want: false},
+		{testName: "[sig-] is empty", want: false},
+		{testName: "sig-network without brackets", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := HasSIGTag(tt.testName); got != tt.want {
+				t.Errorf("HasSIGTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDisruptionTest(t *testing.T) {
+	tests := []struct {
+		testName string
+		want     bool
+	}{
+		{
+			testName: "[sig-api-machinery] disruption/kube-api connection/new should be available throughout the test",
+			want:     true,
+		},
+		{
+			testName: "[sig-network-edge] Application behind service load balancer with PDB remains available using new connections",
+			want:     true,
+		},
+		{
+			testName: "[sig-network-edge] ns/openshift-console route/console connection/reused should be available throughout the test",
+			want:     true,
+		},
+		{
+			testName: "[bz-kube-apiserver] kube-apiserver should not have single second disruptions",
+			want:     true,
+		},
+		{
+			testName: "[sig-arch] alert/KubePodNotReady should not be at or above info in ns/openshift-console",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := IsDisruptionTest(tt.testName); got != tt.want {
+				t.Errorf("IsDisruptionTest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSerialTest(t *testing.T) {
+	tests := []struct {
+		name string
+		test v1.TestInfo
+		want bool
+	}{
+		{
+			name: "serial tag",
+			test: v1.TestInfo{Name: "[sig-network] pods should be ready [Serial]", Suite: "openshift-tests"},
+			want: true,
+		},
+		{
+			name: "serial suite",
+			test: v1.TestInfo{Name: "[sig-network] pods should be ready", Suite: "openshift/conformance/serial"},
+			want: true,
+		},
+		{
+			name: "serial suite tag",
+			test: v1.TestInfo{Name: "[sig-network] pods should be ready [Suite:openshift/conformance/serial]"},
+			want: true,
+		},
+		{
+			name: "serial suite segment",
+			test: v1.TestInfo{Name: "[sig-network] pods should be ready", Suite: "openshift-tests-Serial"},
+			want: true,
+		},
+		{
+			name: "parallel suite",
+			test: v1.TestInfo{Name: "[sig-network] serial pods should be ready", Suite: "openshift/conformance/parallel"},
+			want: false,
+		},
+		{
+			name: "suite containing serial in a word",
+			test: v1.TestInfo{Name: "[sig-network] pods should be ready", Suite: "openshift/nonserial"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSerialTest(&tt.test); got != tt.want {
+				t.Errorf("IsSerialTest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
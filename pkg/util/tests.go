@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
 
 	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
 )
@@ -23,18 +24,149 @@ func ExtractTestField(testName, field string) (results []string) {
 				continue
 			}
 
-			value := strings.TrimSpace(match[i+1])
-			unquoted, err := strconv.Unquote(value)
-			if err == nil {
-				value = unquoted
-			}
-			results = append(results, value)
+			results = append(results, UnquoteField(strings.TrimSpace(match[i+1])))
 		}
 	}
 
 	return results
 }
 
+var tagRegexp = regexp.MustCompile(`\[([^\[\]]*)\]`)
+
+// ExtractTestTags parses the bracketed tags in a test name. A [Key:Value] tag adds Value
+// to the values of Key, and a [Flag] tag, such as [Disruptive] or [sig-storage], adds Flag
+// with no values. For example, "[sig-node][Feature:Foo][Feature:Bar] x [Serial]" yields
+// sig-node and Serial with no values, and Feature with the values Foo and Bar.
+func ExtractTestTags(testName string) map[string][]string {
+	tags := make(map[string][]string)
+	for _, match := range tagRegexp.FindAllStringSubmatch(testName, -1) {
+		key, value, hasValue := strings.Cut(match[1], ":")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if _, ok := tags[key]; !ok {
+			tags[key] = []string{}
+		}
+		if !hasValue {
+			continue
+		}
+		tags[key] = append(tags[key], UnquoteField(strings.TrimSpace(value)))
+	}
+
+	return tags
+}
+
+// ExtractTestParams parses the bracketed key=value parameters in a test name, such as the
+// [when=upgrade] of table-driven tests. They're distinct from Jira style [Key:Value] tags,
+// see ExtractTestTags: a tag is only a parameter if its key, before the first "=", is
+// non-empty and has no colons or whitespace. Values are unquoted, see UnquoteField, and
+// if a key is repeated its first value is used. For example, "[sig-cli][when=upgrade]
+// [arch=arm64] x" yields when=upgrade and arch=arm64.
+func ExtractTestParams(testName string) map[string]string {
+	params := make(map[string]string)
+	for _, match := range tagRegexp.FindAllStringSubmatch(testName, -1) {
+		key, value, ok := strings.Cut(match[1], "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, ": \t") {
+			continue
+		}
+		if _, ok := params[key]; !ok {
+			params[key] = UnquoteField(strings.TrimSpace(value))
+		}
+	}
+
+	return params
+}
+
+// StripTags returns the test name without its bracketed tags, see ExtractTestTags, with the
+// whitespace left behind collapsed, so "[sig-node][Feature:Foo] pods should run [Serial]"
+// becomes "pods should run".
+func StripTags(testName string) string {
+	return strings.Join(strings.Fields(tagRegexp.ReplaceAllString(testName, " ")), " ")
+}
+
+// FindTags returns the bracketed tags in a test name, brackets included, in the order
+// they appear, e.g. "[sig-node]" and "[Serial]" for "[sig-node] pods should run [Serial]".
+// They're the tags ExtractTestTags parses and StripTags removes.
+func FindTags(testName string) []string {
+	return tagRegexp.FindAllString(testName, -1)
+}
+
+// SplitTestHierarchy splits a test name such as Parent/Child/Grandchild into its hierarchy
+// segments. Slashes inside bracketed tags, such as [Suite:openshift/conformance/parallel],
+// don't separate segments. A name without slashes is a single segment.
+func SplitTestHierarchy(testName string) []string {
+	var segments []string
+	depth, start := 0, 0
+	for i, r := range testName {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		case '/':
+			if depth == 0 {
+				segments = append(segments, testName[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(segments, testName[start:])
+}
+
+// UnquoteField removes the quotes around a field value, if any. Values are unquoted as Go
+// string literals, but if that fails, e.g. on an unknown escape sequence or single quotes,
+// a single layer of matching surrounding quotes is stripped instead.
+func UnquoteField(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	if len(value) >= 2 {
+		if first := value[0]; (first == '"' || first == '\'' || first == '`') && value[len(value)-1] == first {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+// SanitizeForStorage returns the test name in a form safe to store as a column value or
+// key: invalid UTF-8 and control characters other than whitespace are removed, and each
+// run of whitespace, including the newlines of multiline names, is collapsed to a single
+// space and trimmed from the ends. Quotes and other printable characters are kept.
+func SanitizeForStorage(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	space := false
+	for _, r := range strings.ToValidUTF8(name, "") {
+		switch {
+		case unicode.IsSpace(r):
+			space = b.Len() > 0
+		case unicode.IsControl(r):
+		default:
+			if space {
+				b.WriteByte(' ')
+				space = false
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// StorageKey returns the key to store the test under, its name sanitized by
+// SanitizeForStorage, prefixed by its suite and a colon if it has one. The test is left
+// unchanged.
+func StorageKey(test *v1.TestInfo) string {
+	name := SanitizeForStorage(test.Name)
+	if test.Suite != "" {
+		return SanitizeForStorage(test.Suite) + ":" + name
+	}
+	return name
+}
+
 // StableID produces a stable test ID based on a TestInfo struct and a stableName.
 func StableID(testInfo *v1.TestInfo, stableName string) string {
 	hash := fmt.Sprintf("%x", md5.Sum([]byte(stableName)))
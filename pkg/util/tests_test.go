@@ -3,6 +3,8 @@ package util
 import (
 	"reflect"
 	"testing"
+
+	v1 "github.com/openshift-eng/ci-test-mapping/pkg/api/types/v1"
 )
 
 func TestExtractField(t *testing.T) {
@@ -63,3 +65,212 @@ func TestExtractField(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitTestHierarchy(t *testing.T) {
+	tests := []struct {
+		name string
+		test string
+		want []string
+	}{
+		{
+			name: "single segment",
+			test: "[sig-storage] volumes should mount",
+			want: []string{"[sig-storage] volumes should mount"},
+		},
+		{
+			name: "subtests",
+			test: "TestUpgrade/control-plane/etcd should be healthy",
+			want: []string{"TestUpgrade", "control-plane", "etcd should be healthy"},
+		},
+		{
+			name: "slashes in bracket tags are kept",
+			test: "[sig-network][Suite:openshift/conformance/parallel] services/should work",
+			want: []string{"[sig-network][Suite:openshift/conformance/parallel] services", "should work"},
+		},
+		{
+			name: "empty segments",
+			test: "Parent//Child/",
+			want: []string{"Parent", "", "Child", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitTestHierarchy(tt.test); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitTestHierarchy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnquoteField(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: `"OLM / Dependency Resolution"`, want: "OLM / Dependency Resolution"},
+		{value: `OLM / Dependency Resolution`, want: "OLM / Dependency Resolution"},
+		{value: `"Networking \"ovn\""`, want: `Networking "ovn"`},
+		{value: `"Storage \d Operators"`, want: `Storage \d Operators`},
+		{value: `'Test Framework'`, want: "Test Framework"},
+		{value: `"Test Framework'`, want: `"Test Framework'`},
+		{value: `""`, want: ""},
+		{value: `"`, want: `"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := UnquoteField(tt.value); got != tt.want {
+				t.Errorf("UnquoteField(%s) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTestTags(t *testing.T) {
+	tests := []struct {
+		name string
+		test string
+		want map[string][]string
+	}{
+		{
+			name: "flags and fields",
+			test: "[sig-storage] In-tree Volumes [Driver: windows-gcepd] [Feature:Windows][Feature:GCE] subPath should work [LinuxOnly] [Suite:openshift/conformance/parallel]",
+			want: map[string][]string{
+				"sig-storage": {},
+				"Driver":      {"windows-gcepd"},
+				"Feature":     {"Windows", "GCE"},
+				"LinuxOnly":   {},
+				"Suite":       {"openshift/conformance/parallel"},
+			},
+		},
+		{
+			name: "quoted value",
+			test: `[Jira:"Test Framework"] should work [Disruptive]`,
+			want: map[string][]string{
+				"Jira":       {"Test Framework"},
+				"Disruptive": {},
+			},
+		},
+		{
+			name: "value containing colons",
+			test: "[sig-network] should work [Skipped:Network/OVNKubernetes:legacy]",
+			want: map[string][]string{
+				"sig-network": {},
+				"Skipped":     {"Network/OVNKubernetes:legacy"},
+			},
+		},
+		{
+			name: "no tags",
+			test: "Cluster upgrade should work",
+			want: map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractTestTags(tt.test); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractTestTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTestParams(t *testing.T) {
+	tests := []struct {
+		name string
+		test string
+		want map[string]string
+	}{
+		{
+			name: "params",
+			test: `[sig-cli][when=upgrade] [arch = "arm64"] oc should work [Feature:Foo]`,
+			want: map[string]string{"when": "upgrade", "arch": "arm64"},
+		},
+		{
+			name: "jira style fields are not params",
+			test: "[sig-cli][Skipped:Network/OVNKubernetes=legacy][when:upgrade] oc should work",
+			want: map[string]string{},
+		},
+		{
+			name: "first value of a repeated key",
+			test: "[when=upgrade][when=install][=bare] oc should work",
+			want: map[string]string{"when": "upgrade"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractTestParams(tt.test); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractTestParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeForStorage(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "[sig-etcd] etcd should be healthy", want: "[sig-etcd] etcd should be healthy"},
+		{name: "[sig-etcd] etcd\n  should be\thealthy\r\n", want: "[sig-etcd] etcd should be healthy"},
+		{name: "  \x00[sig-etcd] etcd \x1bshould\x7f be healthy", want: "[sig-etcd] etcd should be healthy"},
+		{name: `[sig-cli] oc "explain" works`, want: `[sig-cli] oc "explain" works`},
+		{name: "[sig-cli] oc\xff works", want: "[sig-cli] oc works"},
+		{name: "\n\t", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeForStorage(tt.name); got != tt.want {
+				t.Errorf("SanitizeForStorage(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStorageKey(t *testing.T) {
+	test := &v1.TestInfo{Name: "[sig-etcd] etcd\nshould be healthy", Suite: "openshift-tests"}
+	if got, want := StorageKey(test), "openshift-tests:[sig-etcd] etcd should be healthy"; got != want {
+		t.Errorf("StorageKey() = %q, want %q", got, want)
+	}
+	if test.Name != "[sig-etcd] etcd\nshould be healthy" {
+		t.Errorf("StorageKey() modified the test name to %q", test.Name)
+	}
+	if got, want := StorageKey(&v1.TestInfo{Name: "etcd is healthy"}), "etcd is healthy"; got != want {
+		t.Errorf("StorageKey() = %q, want %q", got, want)
+	}
+}
+
+func TestStripTags(t *testing.T) {
+	tests := []struct {
+		testName string
+		want     string
+	}{
+		{testName: "[sig-node][Feature:Foo] pods should run [Serial]", want: "pods should run"},
+		{testName: "[sig-cli] oc adm [apigroup:config.openshift.io] must-gather runs", want: "oc adm must-gather runs"},
+		{testName: "pods should run", want: "pods should run"},
+		{testName: "unbalanced [bracket should stay", want: "unbalanced [bracket should stay"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := StripTags(tt.testName); got != tt.want {
+				t.Errorf("StripTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindTags(t *testing.T) {
+	tests := []struct {
+		testName string
+		want     []string
+	}{
+		{testName: "[sig-node][Feature:Foo] pods should run [Serial]", want: []string{"[sig-node]", "[Feature:Foo]", "[Serial]"}},
+		{testName: "pods should run", want: nil},
+		{testName: "unbalanced [bracket [Serial] should stay", want: []string{"[Serial]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := FindTags(tt.testName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}